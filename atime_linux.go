@@ -0,0 +1,22 @@
+package pathlib
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Returns the observed file's last access time, if available.
+func accessTime(info fs.FileInfo) (atime time.Time, ok bool) {
+	switch sys := info.Sys().(type) {
+	case *MemStat:
+		return sys.Atime, true
+	case *syscall.Stat_t:
+		return time.Unix(sys.Atim.Unix()), true
+	case *unix.Stat_t:
+		return time.Unix(sys.Atim.Unix()), true
+	}
+	return time.Time{}, false
+}
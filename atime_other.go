@@ -0,0 +1,16 @@
+//go:build !linux
+
+package pathlib
+
+import (
+	"io/fs"
+	"time"
+)
+
+// Returns the observed file's last access time, if available.
+func accessTime(info fs.FileInfo) (atime time.Time, ok bool) {
+	if sys, isMem := info.Sys().(*MemStat); isMem {
+		return sys.Atime, true
+	}
+	return time.Time{}, false
+}
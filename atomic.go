@@ -0,0 +1,120 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+)
+
+// A [FileHandle] to a temporary file that replaces its target file when committed.
+// Until then, [FileHandle.Path] refers to the temporary file.
+type AtomicFileHandle interface {
+	FileHandle
+	// The file that Commit replaces.
+	Target() File
+	// Flush the temporary file to stable storage, close it, rename it over the target,
+	// then flush the target's parent directory. Readers see either the old contents
+	// or the new contents, never a mix.
+	Commit() error
+	// Close and remove the temporary file, leaving the target untouched. Abort does
+	// nothing after Commit, so it is safe to defer.
+	Abort() error
+}
+
+type atomicHandle struct {
+	*handle
+	target File
+	done   bool
+}
+
+var _ AtomicFileHandle = &atomicHandle{}
+
+// Start replacing the file's contents. Writes go to a temporary file next to f that
+// is created with the given permissions, subject to the umask. Nothing is visible
+// at f until [AtomicFileHandle.Commit].
+func (f File) CreateAtomic(perm fs.FileMode) (AtomicFileHandle, error) {
+	h, err := createTemp(f.Parent(), "."+f.BaseName()+".tmp-*", perm)
+	if err != nil {
+		return nil, err
+	}
+	return &atomicHandle{handle: h, target: f}, nil
+}
+
+// Replace the file's contents with data, so that readers never observe a partial
+// write. See [File.CreateAtomic].
+func (f File) WriteAtomic(data []byte, perm fs.FileMode) (File, error) {
+	h, err := f.CreateAtomic(perm)
+	if err != nil {
+		return f, err
+	}
+	defer func() { _ = h.Abort() }()
+	if _, err = h.Write(data); err != nil {
+		return f, err
+	}
+	return f, h.Commit()
+}
+
+// Target implements [AtomicFileHandle].
+func (h *atomicHandle) Target() File {
+	return h.target
+}
+
+// Commit implements [AtomicFileHandle].
+func (h *atomicHandle) Commit() error {
+	if h.done {
+		return &fs.PathError{Op: "commit", Path: string(h.target), Err: os.ErrClosed}
+	}
+	err := h.handle.Sync()
+	if closeErr := h.handle.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		_, err = rename(h.Path(), PathStr(h.target))
+	}
+	if err != nil {
+		_ = h.Abort()
+		return err
+	}
+	h.done = true
+	return syncDir(h.target.Parent())
+}
+
+// Abort implements [AtomicFileHandle].
+func (h *atomicHandle) Abort() error {
+	if h.done {
+		return nil
+	}
+	h.done = true
+	_ = h.handle.Close()
+	err := remove(h.Path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Closing an uncommitted handle aborts it. Like Abort, Close does nothing after
+// Commit, so it is safe to defer.
+//
+// Close implements [io.Closer].
+func (h *atomicHandle) Close() error {
+	return h.Abort()
+}
+
+// flushes a directory's entries to stable storage so that a rename within it
+// survives a crash.
+func syncDir(d Dir) error {
+	if runtime.GOOS == "windows" {
+		return nil // directories can't be opened for syncing
+	}
+	f, err := openFile(d, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
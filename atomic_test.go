@@ -0,0 +1,84 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleFile_WriteAtomic() {
+	config := pathlib.TempDir().Join("atomic-example.json").AsFile()
+	defer func() { _ = config.Remove() }()
+
+	expect(config.WriteAtomic([]byte(`{"version": 1}`), 0o644))
+	expect(config.WriteAtomic([]byte(`{"version": 2}`), 0o644))
+	fmt.Println(string(expect(config.Read())))
+	// Output:
+	// {"version": 2}
+}
+
+// lists the names of the directory's entries.
+func names(t *testing.T, d pathlib.Dir) (result []string) {
+	t.Helper()
+	for _, entry := range expect(d.Read()) {
+		result = append(result, entry.Name())
+	}
+	return
+}
+
+func TestFile_CreateAtomic(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		target := writeFile(t, temp.Join("target.txt").AsFile(), "old")
+
+		h := expect(target.CreateAtomic(0o600))
+		if h.Target() != target || h.Path() == target || h.Parent() != temp {
+			t.Errorf("expected a sibling temporary file, got %q", h.Path())
+		}
+		expect(h.WriteString("new"))
+		if data := string(expect(target.Read())); data != "old" {
+			t.Errorf("uncommitted writes should not be visible, got %q", data)
+		}
+		enforce(h.Commit())
+		if data := string(expect(target.Read())); data != "new" {
+			t.Errorf("expected committed contents, got %q", data)
+		}
+		if mode := expect(target.Stat()).Mode(); mode != 0o600 {
+			t.Errorf("expected mode %s, got %s", os.FileMode(0o600), mode)
+		}
+		enforce(h.Abort()) // no-op after Commit
+		enforce(h.Close())
+		if err := h.Commit(); !errors.Is(err, os.ErrClosed) {
+			t.Errorf("expected os.ErrClosed committing twice, got %v", err)
+		}
+		if entries := names(t, temp); len(entries) != 1 {
+			t.Errorf("expected only the target to remain, got %q", entries)
+		}
+
+		h = expect(target.CreateAtomic(0o644))
+		expect(h.WriteString("discarded"))
+		enforce(h.Abort())
+		if data := string(expect(target.Read())); data != "new" {
+			t.Errorf("aborted writes should not be visible, got %q", data)
+		}
+		h = expect(temp.Join("closed.txt").AsFile().CreateAtomic(0o644))
+		enforce(h.Close())
+		if entries := names(t, temp); len(entries) != 1 {
+			t.Errorf("expected temporary files to be removed, got %q", entries)
+		}
+	})
+}
+
+func TestFile_WriteAtomic(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("new.txt").AsFile().WriteAtomic([]byte("hello"), 0o644))
+		if data := string(expect(file.Read())); data != "hello" {
+			t.Errorf("expected %q, got %q", "hello", data)
+		}
+		if _, err := temp.Join("missing/new.txt").AsFile().WriteAtomic(nil, 0o644); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist without a parent directory, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// What to do when the destination of a copy already exists.
+type OverwritePolicy int
+
+const (
+	// Fail with an error matching [fs.ErrExist]. This is the default.
+	NoClobber OverwritePolicy = iota
+	// Replace the existing destination.
+	Overwrite
+	// Replace the existing destination only if the source was modified more recently, like `cp -u`.
+	OverwriteIfNewer
+	// Leave the existing destination alone and carry on.
+	SkipExisting
+)
+
+// Options that control [File.CopyTo], [Dir.CopyTo] and [Symlink.CopyTo].
+type CopyOptions struct {
+	// Copy the exact permission, setuid, setgid and sticky bits. Otherwise, new
+	// files are created with the source's permissions, subject to the umask.
+	PreserveMode bool
+	// Copy the owner and group. This usually requires elevated privileges.
+	PreserveOwnership bool
+	// Copy the access and modification times. Symlinks' own times are not copied.
+	PreserveTimes bool
+	Overwrite     OverwritePolicy
+	// If non-empty, only copy paths beneath the source directory that match. Paths
+	// are matched relative to the source directory.
+	Include Patterns
+	// Don't copy paths beneath the source directory that match, or their contents.
+	// Paths are matched relative to the source directory.
+	Exclude Patterns
+	// If non-nil, called after each file or symlink is copied. Returning an error
+	// stops the copy and returns that error.
+	Progress func(CopyProgress) error
+}
+
+// Reports a file or symlink that has been copied.
+type CopyProgress struct {
+	Source, Destination PathStr
+	// The number of bytes copied from Source.
+	Bytes int64
+	// Running totals for the whole copy operation.
+	TotalFiles int
+	TotalBytes int64
+}
+
+// Copy the file's contents to dst, following symlinks. Returns dst for chaining.
+//
+// On Linux, the copy is attempted as a reflink (FICLONE), then with copy_file_range(2),
+// before falling back to a byte copy.
+func (f File) CopyTo(dst File, opts CopyOptions) (File, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return dst, err
+	}
+	c := copier{CopyOptions: opts}
+	return dst, c.copyFile(PathStr(f), PathStr(dst), info)
+}
+
+// Recursively copy the directory to dst. If dst doesn't exist, it becomes a copy
+// of d; if it does, d's contents are merged into it, subject to [CopyOptions.Overwrite].
+// Symlinks are recreated rather than followed. Returns dst for chaining.
+func (d Dir) CopyTo(dst Dir, opts CopyOptions) (Dir, error) {
+	info, err := d.Stat()
+	if err != nil {
+		return dst, err
+	}
+	if hasPathPrefix(bindingKey(string(dst)), bindingKey(string(d))) {
+		return dst, &fs.PathError{Op: "copy", Path: string(dst), Err: syscall.EINVAL}
+	}
+	c := copier{CopyOptions: opts}
+	return dst, c.copyDir(PathStr(d), PathStr(dst), info, nil)
+}
+
+// Create a symlink at dst with the same target as s. The target is not copied or
+// rewritten, so relative targets are resolved relative to dst. Returns dst for chaining.
+func (s Symlink) CopyTo(dst Symlink, opts CopyOptions) (Symlink, error) {
+	info, err := s.Lstat()
+	if err != nil {
+		return dst, err
+	}
+	c := copier{CopyOptions: opts}
+	return dst, c.copySymlink(PathStr(s), PathStr(dst), info)
+}
+
+type copier struct {
+	CopyOptions
+	files int
+	bytes int64
+}
+
+func (c *copier) copyEntry(src, dst PathStr, info fs.FileInfo, rel []string) error {
+	switch mode := info.Mode(); {
+	case mode.IsDir():
+		return c.copyDir(src, dst, info, rel)
+	case mode&fs.ModeSymlink != 0:
+		return c.copySymlink(src, dst, info)
+	case mode.IsRegular():
+		return c.copyFile(src, dst, info)
+	default:
+		return &fs.PathError{
+			Op:   "copy",
+			Path: string(src),
+			Err:  fmt.Errorf("%w: file mode %s", errors.ErrUnsupported, mode.Type()),
+		}
+	}
+}
+
+func (c *copier) copyDir(src, dst PathStr, info fs.FileInfo, rel []string) error {
+	perm := info.Mode().Perm()
+	// make sure the directory can be filled in, even if the source is read-only
+	err := mkdir(dst, perm|0o700)
+	created := err == nil
+	if errors.Is(err, fs.ErrExist) {
+		// merge into an existing directory, but never through a symlink
+		_, err = expectDir(lstat(Dir(dst)))
+	}
+	if err != nil {
+		return err
+	}
+	entries, err := readDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		parts := append(rel[:len(rel):len(rel)], entry.Name())
+		if !c.included(parts, entry.IsDir()) {
+			continue
+		}
+		child := src.Join(entry.Name())
+		childInfo, err := lstat(child)
+		if err != nil {
+			return err
+		}
+		if err = c.copyEntry(child, dst.Join(entry.Name()), childInfo, parts); err != nil {
+			return err
+		}
+	}
+	if created && !c.PreserveMode && perm&0o700 != 0o700 {
+		if err = chmod(dst, perm); err != nil {
+			return err
+		}
+	}
+	return c.preserve(dst, info)
+}
+
+// reports whether the path relative to the source directory passes the filters.
+func (c *copier) included(parts []string, isDir bool) bool {
+	if c.Exclude.match(parts) {
+		return false
+	}
+	if len(c.Include) == 0 || c.Include.match(parts) {
+		return true
+	}
+	// keep directories that might contain included paths
+	return isDir && c.Include.matchPrefix(parts)
+}
+
+// returns true if the destination should be left alone, or an error if it exists
+// and mustn't be overwritten. Also returns the destination's own info, if it exists.
+func (c *copier) skip(src, dst PathStr, info fs.FileInfo) (existing fs.FileInfo, skip bool, err error) {
+	existing, err = filesystemOf(string(dst)).Lstat(string(dst))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	if sameFile(info, existing) {
+		return existing, false, &fs.PathError{Op: "copy", Path: string(dst), Err: fmt.Errorf("%w: same file as %s", syscall.EINVAL, src)}
+	}
+	switch c.Overwrite {
+	case Overwrite:
+		return existing, false, nil
+	case OverwriteIfNewer:
+		return existing, !info.ModTime().After(existing.ModTime()), nil
+	case SkipExisting:
+		return existing, true, nil
+	default:
+		return existing, false, &fs.PathError{Op: "copy", Path: string(dst), Err: fs.ErrExist}
+	}
+}
+
+func (c *copier) copyFile(src, dst PathStr, info fs.FileInfo) (err error) {
+	existing, skip, err := c.skip(src, dst, info)
+	if skip || err != nil {
+		return err
+	}
+	if existing != nil && existing.Mode()&fs.ModeSymlink != 0 {
+		// replace the link itself rather than writing through it
+		if err = remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	in, err := filesystemOf(string(src)).OpenFile(string(src), os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := filesystemOf(string(dst)).OpenFile(string(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	n, err := copyContents(out, in)
+	if err != nil {
+		return err
+	}
+	if err = c.preserve(dst, info); err != nil {
+		return err
+	}
+	return c.progress(src, dst, n)
+}
+
+func (c *copier) copySymlink(src, dst PathStr, info fs.FileInfo) error {
+	if _, skip, err := c.skip(src, dst, info); skip || err != nil {
+		return err
+	}
+	target, err := readlink(src)
+	if err != nil {
+		return err
+	}
+	if c.Overwrite != NoClobber {
+		// symlink(2) won't replace an existing path
+		if err = remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	if err = symlink(dst, target); err != nil {
+		return err
+	}
+	if c.PreserveOwnership {
+		if uid, gid, ok := ownerOf(info); ok {
+			if err = filesystemOf(string(dst)).Lchown(string(dst), uid, gid); err != nil {
+				return err
+			}
+		}
+	}
+	return c.progress(src, dst, 0)
+}
+
+// copies ownership, mode and timestamps from the source's info, as requested.
+func (c *copier) preserve(dst PathStr, info fs.FileInfo) error {
+	fsys := filesystemOf(string(dst))
+	// chown first, since it may clear the setuid and setgid bits
+	if c.PreserveOwnership {
+		if uid, gid, ok := ownerOf(info); ok {
+			if err := fsys.Chown(string(dst), uid, gid); err != nil {
+				return err
+			}
+		}
+	}
+	if c.PreserveMode {
+		if err := fsys.Chmod(string(dst), info.Mode()&chmodMask); err != nil {
+			return err
+		}
+	}
+	if c.PreserveTimes {
+		atime, _ := accessTime(info) // a zero atime is left unchanged
+		if err := fsys.Chtimes(string(dst), atime, info.ModTime()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *copier) progress(src, dst PathStr, n int64) error {
+	c.files++
+	c.bytes += n
+	if c.Progress == nil {
+		return nil
+	}
+	return c.Progress(CopyProgress{
+		Source:      src,
+		Destination: dst,
+		Bytes:       n,
+		TotalFiles:  c.files,
+		TotalBytes:  c.bytes,
+	})
+}
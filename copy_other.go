@@ -0,0 +1,10 @@
+//go:build !linux
+
+package pathlib
+
+import "io"
+
+// Copies src's contents into the empty file dst, returning the number of bytes copied.
+func copyContents(dst, src RawFile) (int64, error) {
+	return io.Copy(dst, src)
+}
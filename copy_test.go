@@ -0,0 +1,241 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDir_CopyTo() {
+	src := expect(pathlib.TempDir().Join("dir-copy-example").AsDir().Make(0o755))
+	defer func() { _, _ = src.RemoveAll() }()
+	for _, name := range []string{"a.go", "a_test.go", "sub/b.go", "vendor/c.go"} {
+		enforce(expect(src.Join(name).AsFile().MakeAll(0o644, 0o755)).Close())
+	}
+
+	dst := pathlib.TempDir().Join("dir-copy-example.bak").AsDir()
+	defer func() { _, _ = dst.RemoveAll() }()
+	_, err := src.CopyTo(dst, pathlib.CopyOptions{
+		Include: expect(pathlib.CompilePatterns("**/*.go")),
+		Exclude: expect(pathlib.CompilePatterns("vendor", "**/*_test.go")),
+		Progress: func(p pathlib.CopyProgress) error {
+			fmt.Println(expect(p.Destination.Rel(dst)))
+			return nil
+		},
+	})
+	enforce(err)
+	// Output:
+	// a.go
+	// sub/b.go
+}
+
+// replaces the file's content, creating it and its parents if necessary.
+func writeFile(t *testing.T, f pathlib.File, content string) pathlib.File {
+	t.Helper()
+	h := expect(f.MakeAll(0o644, 0o755))
+	enforce(h.Truncate(0))
+	expect(h.WriteString(content))
+	enforce(h.Close())
+	return f
+}
+
+func TestFile_CopyTo(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		src := writeFile(t, temp.Join("src.txt").AsFile(), "hello")
+		enforce(src.Chmod(0o640))
+
+		dst := expect(src.CopyTo(temp.Join("dst.txt").AsFile(), pathlib.CopyOptions{}))
+		if data := string(expect(dst.Read())); data != "hello" {
+			t.Errorf("expected %q, got %q", "hello", data)
+		}
+		if mode := expect(dst.Stat()).Mode(); mode != 0o640 {
+			t.Errorf("expected mode %s, got %s", fs.FileMode(0o640), mode)
+		}
+
+		if _, err := src.CopyTo(dst, pathlib.CopyOptions{}); !errors.Is(err, fs.ErrExist) {
+			t.Errorf("expected fs.ErrExist, got %v", err)
+		}
+		if _, err := src.CopyTo(src, pathlib.CopyOptions{Overwrite: pathlib.Overwrite}); !errors.Is(err, syscall.EINVAL) {
+			t.Errorf("expected EINVAL copying a file onto itself, got %v", err)
+		}
+		if data := string(expect(src.Read())); data != "hello" {
+			t.Errorf("copying onto itself clobbered the source: %q", data)
+		}
+
+		writeFile(t, dst, "old")
+		expect(src.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.SkipExisting}))
+		if data := string(expect(dst.Read())); data != "old" {
+			t.Errorf("SkipExisting overwrote the destination: %q", data)
+		}
+		expect(src.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.Overwrite}))
+		if data := string(expect(dst.Read())); data != "hello" {
+			t.Errorf("Overwrite didn't overwrite the destination: %q", data)
+		}
+	})
+}
+
+func TestFile_CopyTo_ifNewer(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		src := writeFile(t, temp.Join("src.txt").AsFile(), "new")
+		dst := writeFile(t, temp.Join("dst.txt").AsFile(), "old")
+		past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+		fsys := pathlib.FilesystemOf(temp)
+
+		enforce(fsys.Chtimes(dst.String(), future, future))
+		expect(src.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.OverwriteIfNewer}))
+		if data := string(expect(dst.Read())); data != "old" {
+			t.Errorf("a newer destination was overwritten: %q", data)
+		}
+		enforce(fsys.Chtimes(dst.String(), past, past))
+		expect(src.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.OverwriteIfNewer}))
+		if data := string(expect(dst.Read())); data != "new" {
+			t.Errorf("an older destination wasn't overwritten: %q", data)
+		}
+	})
+}
+
+func TestDir_CopyTo(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		src := temp.Join("src").AsDir()
+		writeFile(t, src.Join("a.txt").AsFile(), "a")
+		writeFile(t, src.Join("nested/b.txt").AsFile(), "bb")
+		expect(src.Join("link").AsSymlink().LinkTo("nested/b.txt"))
+		expect(src.Join("empty").AsDir().Make(0o700))
+		mtime := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		enforce(pathlib.FilesystemOf(src).Chtimes(src.Join("a.txt").String(), mtime, mtime))
+
+		var progress []pathlib.CopyProgress
+		dst := expect(src.CopyTo(temp.Join("dst").AsDir(), pathlib.CopyOptions{
+			PreserveMode:  true,
+			PreserveTimes: true,
+			Progress: func(p pathlib.CopyProgress) error {
+				progress = append(progress, p)
+				return nil
+			},
+		}))
+
+		if data := string(expect(dst.Join("nested/b.txt").AsFile().Read())); data != "bb" {
+			t.Errorf("unexpected contents %q", data)
+		}
+		if target := expect(dst.Join("link").AsSymlink().Read()); target != "nested/b.txt" {
+			t.Errorf("expected the link to be recreated verbatim, got %q", target)
+		}
+		if mode := expect(dst.Join("empty").AsDir().Stat()).Mode(); mode != fs.ModeDir|0o700 {
+			t.Errorf("expected mode to be preserved, got %s", mode)
+		}
+		if actual := expect(dst.Join("a.txt").AsFile().Stat()).ModTime(); !actual.Equal(mtime) {
+			t.Errorf("expected mtime %s, got %s", mtime, actual)
+		}
+
+		var copied []string
+		for _, p := range progress {
+			copied = append(copied, expect(p.Source.Rel(src)).String())
+		}
+		if expected := []string{"a.txt", "link", "nested/b.txt"}; !slices.Equal(copied, expected) {
+			t.Errorf("expected progress for %q, got %q", expected, copied)
+		}
+		if last := progress[len(progress)-1]; last.TotalFiles != 3 || last.TotalBytes != 3 {
+			t.Errorf("unexpected totals %+v", last)
+		}
+
+		if _, err := src.CopyTo(src.Join("inside").AsDir(), pathlib.CopyOptions{}); !errors.Is(err, syscall.EINVAL) {
+			t.Errorf("expected EINVAL copying a directory into itself, got %v", err)
+		}
+		stop := errors.New("stop")
+		_, err := src.CopyTo(temp.Join("stopped").AsDir(), pathlib.CopyOptions{
+			Progress: func(pathlib.CopyProgress) error { return stop },
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("expected the progress callback's error, got %v", err)
+		}
+	})
+}
+
+func TestDir_CopyTo_acrossBackends(t *testing.T) {
+	src := pathlib.Dir(t.TempDir())
+	writeFile(t, src.Join("a/b.txt").AsFile(), "on disk")
+	dst := memDir(t).Join("copy").AsDir()
+
+	expect(src.CopyTo(dst, pathlib.CopyOptions{}))
+	if data := string(expect(dst.Join("a/b.txt").AsFile().Read())); data != "on disk" {
+		t.Errorf("unexpected contents %q", data)
+	}
+	if _, err := os.Stat(dst.String()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("the copy should only exist in memory, got %v", err)
+	}
+}
+
+func TestSymlink_CopyTo(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		link := expect(temp.Join("link").AsSymlink().LinkTo("missing"))
+		dst := expect(link.CopyTo(temp.Join("copy").AsSymlink(), pathlib.CopyOptions{}))
+		if target := expect(dst.Read()); target != "missing" {
+			t.Errorf("expected a dangling link to be copied verbatim, got %q", target)
+		}
+		other := expect(temp.Join("other").AsSymlink().LinkTo("elsewhere"))
+		if _, err := other.CopyTo(dst, pathlib.CopyOptions{}); !errors.Is(err, fs.ErrExist) {
+			t.Errorf("expected fs.ErrExist, got %v", err)
+		}
+		expect(other.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.Overwrite}))
+		if target := expect(dst.Read()); target != "elsewhere" {
+			t.Errorf("expected the link to be replaced, got %q", target)
+		}
+	})
+}
+
+func TestFile_CopyTo_symlinkDestination(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		src := writeFile(t, temp.Join("src").AsFile(), "new")
+		outside := writeFile(t, temp.Join("outside").AsFile(), "untouched")
+		dst := temp.Join("dst").AsFile()
+		expect(pathlib.Symlink(dst).LinkTo(pathlib.PathStr(outside)))
+
+		expect(src.CopyTo(dst, pathlib.CopyOptions{Overwrite: pathlib.Overwrite}))
+		if data := expect(outside.ReadString()); data != "untouched" {
+			t.Errorf("expected the link's target to be left alone, got %q", data)
+		}
+		if info := expect(dst.Lstat()); !info.Mode().IsRegular() {
+			t.Errorf("expected the link to be replaced by a file, got %s", info.Mode())
+		}
+		if data := expect(dst.ReadString()); data != "new" {
+			t.Errorf("unexpected contents %q", data)
+		}
+	})
+}
+
+func TestDir_CopyTo_merge(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		src := temp.Join("src").AsDir()
+		writeFile(t, src.Join("a.txt").AsFile(), "a")
+		enforce(src.Chmod(0o500))
+		defer func() { enforce(src.Chmod(0o755)) }()
+
+		existing := expect(temp.Join("existing").AsDir().Make(0o755))
+		expect(src.CopyTo(existing, pathlib.CopyOptions{}))
+		if mode := expect(existing.Stat()).Mode(); mode != fs.ModeDir|0o755 {
+			t.Errorf("expected an existing directory's mode to be kept, got %s", mode)
+		}
+		created := expect(src.CopyTo(temp.Join("created").AsDir(), pathlib.CopyOptions{}))
+		if mode := expect(created.Stat()).Mode(); mode != fs.ModeDir|0o500 {
+			t.Errorf("expected a new directory to get the source's mode, got %s", mode)
+		}
+		enforce(created.Chmod(0o755))
+
+		target := expect(temp.Join("target").AsDir().Make(0o755))
+		link := expect(temp.Join("link").AsSymlink().LinkTo(pathlib.PathStr(target)))
+		var wrongType pathlib.WrongTypeOnDisk[pathlib.Dir]
+		if _, err := src.CopyTo(link.Join().AsDir(), pathlib.CopyOptions{}); !errors.As(err, &wrongType) {
+			t.Errorf("expected a WrongTypeOnDisk error merging through a symlink, got %v", err)
+		}
+		if entries := expect(target.Read()); len(entries) != 0 {
+			t.Errorf("expected nothing to be copied through the link, got %d entries", len(entries))
+		}
+	})
+}
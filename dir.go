@@ -3,33 +3,53 @@ package pathlib
 import (
 	"io/fs"
 	"os"
-	"path/filepath"
+	"strings"
+	"time"
 )
 
 // A string that represents a directory. The directory may or may not exist on-disk,
 // and the string may or may not end in an [os.PathSeparator].
 type Dir PathStr
 
-// See [path/filepath.WalkDir].
+// See [path/filepath.WalkDir]. For a lazy iterator that yields each path as an
+// [Entry], see [Dir.WalkSeq].
 func (d Dir) Walk(
 	callback func(path PathStr, d fs.DirEntry, err error) error,
 ) error {
-	return filepath.WalkDir(string(d), func(path string, d fs.DirEntry, err error) error {
+	return walkDir(string(d), func(path string, d fs.DirEntry, err error) error {
 		return callback(PathStr(path), d, err)
 	})
 }
 
-// TODO: walk -> iter.Seq[PathStr]?
-
-// See [path/filepath.Glob].
+// Returns every path that matches the pattern joined onto d. Like
+// [path/filepath.Glob], I/O errors are ignored, and the pattern may lead outside d
+// with ".." or a literal prefix. Symlinks to directories are followed as described
+// in [Dir.GlobSeq]. See [Pattern] for the syntax, and [Dir.GlobSeq] for a lazy
+// alternative.
+//
+// A pattern starting with "!" matches every path beneath d that the rest of the
+// pattern doesn't.
 func (d Dir) Glob(pattern string) ([]PathStr, error) {
-	matches, err := filepath.Glob(filepath.Join(string(d), pattern))
+	base, rest := d, pattern
+	if !strings.HasPrefix(pattern, "!") {
+		base, rest = globBase(d, pattern)
+	}
+	p, err := CompilePattern(rest)
 	if err != nil {
 		return nil, err
 	}
-	result := make([]PathStr, len(matches))
-	for i, m := range matches {
-		result[i] = PathStr(m)
+	if rest == "" {
+		// nothing to match, so the pattern names a single path
+		if _, err := lstat(base); err != nil {
+			return nil, nil
+		}
+		return []PathStr{PathStr(base)}, nil
+	}
+	var result []PathStr
+	for match, err := range base.GlobSeq(p) {
+		if err == nil {
+			result = append(result, match.Path())
+		}
 	}
 	return result, nil
 }
@@ -49,15 +69,17 @@ func (d Dir) RemoveAll() (Dir, error) {
 // Readable --------------------------------------------------------------------
 var _ Readable[[]fs.DirEntry] = Dir(".")
 
-// See [os.ReadDir].
+// See [os.ReadDir]. [Dir.ReadEntries] returns each entry as an [Entry], and
+// [Dir.Entries] lists them lazily.
 //
 // Read implements [Reader].
 func (d Dir) Read() ([]fs.DirEntry, error) {
-	return os.ReadDir(string(d))
+	return readDir(d)
 }
 
 // PurePath --------------------------------------------------------------------
 var _ PurePath = Dir(".")
+var _ Stemmer = Dir(".")
 
 // See [filepath.Base].
 //
@@ -98,8 +120,24 @@ func (d Dir) Ext() string {
 	return ext(d)
 }
 
+// Stem implements [Stemmer].
+func (d Dir) Stem() string {
+	return stem(d)
+}
+
+// Suffixes implements [Stemmer].
+func (d Dir) Suffixes() []string {
+	return suffixes(d)
+}
+
+// IsRelativeTo implements [PureTransformer].
+func (d Dir) IsRelativeTo(base Dir) bool {
+	return isRelativeTo(d, base)
+}
+
 // Transformer -----------------------------------------------------------------
 var _ Transformer[Dir] = Dir(".")
+var _ PureTransformer[Dir] = Dir(".")
 
 // Convenience method to cast get the untyped string representation of the path.
 //
@@ -155,6 +193,34 @@ func (d Dir) ExpandUser() (Dir, error) {
 	return expandUser(d)
 }
 
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (d Dir) WithName(name string) (Dir, error) {
+	return withName(d, name)
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (d Dir) WithStem(stem string) (Dir, error) {
+	return withStem(d, stem)
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (d Dir) WithSuffix(suffix string) (Dir, error) {
+	return withSuffix(d, suffix)
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (d Dir) RelativeTo(base Dir) (Dir, error) {
+	return relativeTo(d, base)
+}
+
 // Beholder --------------------------------------------------------------------
 var _ Beholder[Dir] = Dir(".")
 
@@ -199,7 +265,7 @@ var _ Maker[Dir] = Dir("/example")
 //
 // Make implements [Maker].
 func (d Dir) Make(perm fs.FileMode) (result Dir, err error) {
-	return d, os.Mkdir(string(d), perm)
+	return d, mkdir(d, perm)
 }
 
 // MakeAll implements [Maker]
@@ -212,12 +278,13 @@ func (d Dir) MakeAll(perm, parentPerm fs.FileMode) (result Dir, err error) {
 	if err != nil {
 		return
 	}
-	err = os.MkdirAll(string(d), perm)
+	err = mkdirAll(d, perm)
 	return
 }
 
 // Changer ----------------------------------------------------------------------
 var _ Changer = Dir(".")
+var _ TimeChanger = Dir(".")
 
 // See [os.Chmod].
 //
@@ -233,6 +300,13 @@ func (d Dir) Chown(uid int, gid int) error {
 	return chown(d, uid, gid)
 }
 
+// Follows symlinks.
+//
+// Chtimes implements [TimeChanger].
+func (d Dir) Chtimes(atime, mtime time.Time) error {
+	return chtimes(d, atime, mtime, true)
+}
+
 // Remover -----------------------------------------------------------------------
 
 var _ Remover[Dir] = Dir(".")
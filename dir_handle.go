@@ -0,0 +1,149 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// An open directory whose methods resolve names relative to the held file
+// descriptor using openat(2), mkdirat(2) and friends, so that renaming or replacing
+// any of the directory's ancestors can't redirect them. Only supported on Unix, for
+// directories on the [OS] filesystem.
+//
+// Methods that take a follow flag act on a final symlink itself when it is false, like
+// O_NOFOLLOW and AT_SYMLINK_NOFOLLOW. Intermediate symlinks are always followed.
+type DirHandle struct {
+	f   *os.File
+	dir Dir
+}
+
+// Opens the directory for fd-relative operations. Follows symlinks.
+func (d Dir) Open() (*DirHandle, error) {
+	if _, ok := filesystemOf(string(d)).(OS); !ok {
+		return nil, &fs.PathError{Op: "open", Path: string(d), Err: errors.ErrUnsupported}
+	}
+	f, err := openDirectory(string(d))
+	if err != nil {
+		return nil, err
+	}
+	return &DirHandle{f, d}, nil
+}
+
+// Returns the path the directory was opened from. It may no longer lead to the
+// directory if an ancestor was renamed.
+func (h *DirHandle) Path() Dir {
+	return h.dir
+}
+
+// Returns the path of a name within the directory.
+func (h *DirHandle) Join(name string) PathStr {
+	return h.dir.Join(name)
+}
+
+// See [os.File.Close].
+func (h *DirHandle) Close() error {
+	return h.f.Close()
+}
+
+// See [os.File.Fd].
+func (h *DirHandle) Fd() uintptr {
+	return h.f.Fd()
+}
+
+// Opens a file relative to the directory. See openat(2) and [File.Open].
+func (h *DirHandle) OpenFile(name string, flag int, perm fs.FileMode, follow bool) (FileHandle, error) {
+	f, err := h.openat(name, flag, perm, follow)
+	if err != nil {
+		return nil, err
+	}
+	return &handle{f}, nil
+}
+
+// Opens a subdirectory relative to the directory.
+func (h *DirHandle) OpenDir(name string, follow bool) (*DirHandle, error) {
+	f, err := h.openat(name, openDirFlags, 0, follow)
+	if err != nil {
+		return nil, err
+	}
+	return &DirHandle{f, Dir(h.Join(name))}, nil
+}
+
+// Returns the info of a name relative to the directory, or of the directory itself
+// for ".". See fstatat(2).
+func (h *DirHandle) Stat(name string, follow bool) (Info[PathStr], error) {
+	return dirHandleInfo[PathStr](h, name, follow)
+}
+
+// Like [Dir.Stat], failing with [WrongTypeOnDisk] if the name isn't a directory.
+func (h *DirHandle) StatDir(name string) (Info[Dir], error) {
+	return expectDir(dirHandleInfo[Dir](h, name, true))
+}
+
+// Like [File.Stat], failing with [WrongTypeOnDisk] if the name isn't a regular file.
+func (h *DirHandle) StatFile(name string) (Info[File], error) {
+	return expectFile(dirHandleInfo[File](h, name, true))
+}
+
+// Like [Symlink.Lstat], failing with [WrongTypeOnDisk] if the name isn't a symlink.
+func (h *DirHandle) LstatSymlink(name string) (Info[Symlink], error) {
+	return expectSymlink(dirHandleInfo[Symlink](h, name, false))
+}
+
+func dirHandleInfo[P Kind](h *DirHandle, name string, follow bool) (Info[P], error) {
+	info, err := h.fstatat(name, follow)
+	if err != nil {
+		return nil, err
+	}
+	return onDisk[P]{P(h.Join(name)), info}, nil
+}
+
+// Makes a subdirectory. See mkdirat(2).
+func (h *DirHandle) Make(name string, perm fs.FileMode) (Dir, error) {
+	return Dir(h.Join(name)), h.mkdirat(name, perm)
+}
+
+// Makes a subdirectory and any missing parents, opening each one in turn so that
+// none of them can be swapped out midway. Unlike [Dir.MakeAll], an existing directory
+// isn't checked for and then made in separate steps.
+func (h *DirHandle) MakeAll(name string, perm fs.FileMode) (Dir, error) {
+	result := Dir(h.Join(name))
+	parent := h
+	for _, part := range hostFlavor.parts(name) {
+		if err := parent.mkdirat(part, perm); err != nil && !errors.Is(err, fs.ErrExist) {
+			return result, err
+		}
+		child, err := parent.OpenDir(part, true)
+		if parent != h {
+			_ = parent.Close()
+		}
+		if err != nil {
+			return result, err
+		}
+		parent = child
+	}
+	if parent != h {
+		return result, parent.Close()
+	}
+	return result, nil
+}
+
+// Removes a file, symlink or empty subdirectory. See unlinkat(2) and [os.Remove].
+func (h *DirHandle) Remove(name string) error {
+	return h.unlinkat(name)
+}
+
+// Renames oldName relative to this directory to newName relative to newDir, or to
+// this directory if newDir is nil. Symlinks in the final components aren't
+// followed. See renameat(2).
+func (h *DirHandle) Rename(oldName string, newDir *DirHandle, newName string) (PathStr, error) {
+	if newDir == nil {
+		newDir = h
+	}
+	return newDir.Join(newName), h.renameat(oldName, newDir, newName)
+}
+
+// Makes a symlink named name that points to target. See symlinkat(2).
+func (h *DirHandle) Symlink(target, name string) (Symlink, error) {
+	return Symlink(h.Join(name)), h.symlinkat(target, name)
+}
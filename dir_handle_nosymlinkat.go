@@ -0,0 +1,12 @@
+//go:build aix || solaris
+
+package pathlib
+
+import (
+	"errors"
+	"os"
+)
+
+func (h *DirHandle) symlinkat(target, name string) error {
+	return &os.LinkError{Op: "symlinkat", Old: target, New: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
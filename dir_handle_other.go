@@ -0,0 +1,39 @@
+//go:build !unix
+
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+const openDirFlags = os.O_RDONLY
+
+func openDirectory(name string) (*os.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) openat(name string, flag int, perm fs.FileMode, follow bool) (*os.File, error) {
+	return nil, &fs.PathError{Op: "openat", Path: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) fstatat(name string, follow bool) (fs.FileInfo, error) {
+	return nil, &fs.PathError{Op: "fstatat", Path: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) mkdirat(name string, perm fs.FileMode) error {
+	return &fs.PathError{Op: "mkdirat", Path: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) unlinkat(name string) error {
+	return &fs.PathError{Op: "unlinkat", Path: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) renameat(oldName string, newDir *DirHandle, newName string) error {
+	return &os.LinkError{Op: "renameat", Old: string(h.Join(oldName)), New: string(newDir.Join(newName)), Err: errors.ErrUnsupported}
+}
+
+func (h *DirHandle) symlinkat(target, name string) error {
+	return &os.LinkError{Op: "symlinkat", Old: target, New: string(h.Join(name)), Err: errors.ErrUnsupported}
+}
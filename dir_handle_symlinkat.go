@@ -0,0 +1,19 @@
+//go:build unix && !aix && !solaris
+
+package pathlib
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func (h *DirHandle) symlinkat(target, name string) error {
+	err := h.control(func(dirfd int) error {
+		return unix.Symlinkat(target, dirfd, name)
+	})
+	if err != nil {
+		return &os.LinkError{Op: "symlinkat", Old: target, New: string(h.Join(name)), Err: err}
+	}
+	return nil
+}
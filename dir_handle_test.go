@@ -0,0 +1,115 @@
+package pathlib_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func TestDirHandle(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	dir := expect(temp.Join("dir").AsDir().Make(0o755))
+	h := expect(dir.Open())
+	defer h.Close()
+	if h.Path() != dir {
+		t.Errorf("expected %q, got %q", dir, h.Path())
+	}
+
+	f := expect(h.OpenFile("file", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644, false))
+	expect(f.WriteString("content"))
+	enforce(f.Close())
+	if f.Path() != dir.Join("file").AsFile() {
+		t.Errorf("unexpected path %q", f.Path())
+	}
+
+	link := expect(h.Symlink("file", "link"))
+	if link != dir.Join("link").AsSymlink() || expect(link.Read()) != "file" {
+		t.Errorf("unexpected symlink %q", link)
+	}
+	if _, err := h.OpenFile("link", os.O_RDONLY, 0, false); err == nil {
+		t.Error("expected O_NOFOLLOW to refuse the symlink")
+	}
+	f = expect(h.OpenFile("link", os.O_RDONLY, 0, true))
+	if content := string(expect(io.ReadAll(f))); content != "content" {
+		t.Errorf("unexpected content %q", content)
+	}
+	enforce(f.Close())
+
+	if info := expect(h.Stat("link", false)); info.Mode()&fs.ModeSymlink == 0 {
+		t.Errorf("expected a symlink, got %s", info.Mode())
+	}
+	if info := expect(h.Stat("link", true)); !info.Mode().IsRegular() || info.Size() != 7 {
+		t.Errorf("expected the target's info, got %s", info.Mode())
+	}
+	if !expect(h.Stat(".", true)).IsDir() {
+		t.Error("expected . to be the directory")
+	}
+	if info := expect(h.StatFile("link")); info.Path() != dir.Join("link").AsFile() {
+		t.Errorf("unexpected info path %q", info.Path())
+	}
+	if same := expect(pathlib.SameFile(expect(h.StatFile("file")).Path(), dir.Join("file").AsFile())); !same {
+		t.Error("expected the same file")
+	}
+	expect(h.LstatSymlink("link"))
+	var wrong pathlib.WrongTypeOnDisk[pathlib.Dir]
+	if _, err := h.StatDir("file"); !errors.As(err, &wrong) {
+		t.Errorf("expected WrongTypeOnDisk, got %v", err)
+	}
+	if _, err := h.Stat("missing", true); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+
+	sub := expect(h.Make("sub", 0o755))
+	if sub != dir.Join("sub").AsDir() || !sub.Exists() {
+		t.Errorf("expected %q to be made", sub)
+	}
+	if _, err := h.Make("sub", 0o755); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected ErrExist, got %v", err)
+	}
+	deep := expect(h.MakeAll("sub/a/b", 0o755))
+	if !deep.Exists() {
+		t.Errorf("expected %q to be made", deep)
+	}
+	expect(h.MakeAll("sub/a/b", 0o755))
+	if _, err := h.MakeAll("file/a", 0o755); err == nil {
+		t.Error("expected an error when a parent is a file")
+	}
+
+	subHandle := expect(h.OpenDir("sub", true))
+	defer subHandle.Close()
+	if _, err := h.OpenDir("file", true); err == nil {
+		t.Error("expected an error opening a file as a directory")
+	}
+	moved := expect(h.Rename("file", subHandle, "moved"))
+	if moved != dir.Join("sub", "moved") || !moved.Exists() {
+		t.Errorf("unexpected rename result %q", moved)
+	}
+	expect(h.Rename("link", nil, "renamed-link"))
+	expect(h.LstatSymlink("renamed-link"))
+
+	enforce(h.Remove("renamed-link"))
+	enforce(subHandle.Remove("a/b"))
+	if err := h.Remove("sub"); err == nil {
+		t.Error("expected an error removing a non-empty directory")
+	}
+	if err := h.Remove("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+
+	// operations keep following the directory after it's renamed
+	expect(dir.Rename(temp.Join("elsewhere")))
+	expect(h.Make("after", 0o755))
+	if !temp.Join("elsewhere", "after").AsDir().Exists() {
+		t.Error("expected the handle to follow the renamed directory")
+	}
+}
+
+func TestDirHandle_unsupported(t *testing.T) {
+	if _, err := memDir(t).Open(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
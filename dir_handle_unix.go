@@ -0,0 +1,129 @@
+//go:build unix
+
+package pathlib
+
+import (
+	"io/fs"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const openDirFlags = unix.O_RDONLY | unix.O_DIRECTORY
+
+func openDirectory(name string) (*os.File, error) {
+	return os.OpenFile(name, openDirFlags, 0)
+}
+
+// runs fn with the directory's file descriptor.
+func (h *DirHandle) control(fn func(dirfd int) error) error {
+	return control(h.f, func(fd uintptr) error { return fn(int(fd)) })
+}
+
+func (h *DirHandle) openat(name string, flag int, perm fs.FileMode, follow bool) (*os.File, error) {
+	flag |= unix.O_CLOEXEC
+	if !follow {
+		flag |= unix.O_NOFOLLOW
+	}
+	var fd int
+	err := h.control(func(dirfd int) (err error) {
+		for {
+			fd, err = unix.Openat(dirfd, name, flag, uint32(perm.Perm()))
+			if err != unix.EINTR {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return nil, &fs.PathError{Op: "openat", Path: string(h.Join(name)), Err: err}
+	}
+	return os.NewFile(uintptr(fd), string(h.Join(name))), nil
+}
+
+func (h *DirHandle) fstatat(name string, follow bool) (info fs.FileInfo, err error) {
+	err = h.control(func(dirfd int) (err error) {
+		info, err = fstatat(dirfd, name, follow)
+		return
+	})
+	if err != nil {
+		return nil, &fs.PathError{Op: "fstatat", Path: string(h.Join(name)), Err: err}
+	}
+	return info, nil
+}
+
+func (h *DirHandle) mkdirat(name string, perm fs.FileMode) error {
+	err := h.control(func(dirfd int) error {
+		return unix.Mkdirat(dirfd, name, uint32(perm.Perm()))
+	})
+	if err != nil {
+		return &fs.PathError{Op: "mkdirat", Path: string(h.Join(name)), Err: err}
+	}
+	return nil
+}
+
+func (h *DirHandle) unlinkat(name string) error {
+	err := h.control(func(dirfd int) error {
+		// like os.Remove, try both and report the more interesting error
+		err := unix.Unlinkat(dirfd, name, 0)
+		if err == nil {
+			return nil
+		}
+		rmdirErr := unix.Unlinkat(dirfd, name, unix.AT_REMOVEDIR)
+		if rmdirErr == nil {
+			return nil
+		}
+		if rmdirErr != unix.ENOTDIR {
+			err = rmdirErr
+		}
+		return err
+	})
+	if err != nil {
+		return &fs.PathError{Op: "unlinkat", Path: string(h.Join(name)), Err: err}
+	}
+	return nil
+}
+
+func (h *DirHandle) renameat(oldName string, newDir *DirHandle, newName string) error {
+	err := h.control(func(oldfd int) error {
+		return newDir.control(func(newfd int) error {
+			return unix.Renameat(oldfd, oldName, newfd, newName)
+		})
+	})
+	if err != nil {
+		return &os.LinkError{Op: "renameat", Old: string(h.Join(oldName)), New: string(newDir.Join(newName)), Err: err}
+	}
+	return nil
+}
+
+// builds an [fs.FileInfo] from fstatat(2). Sys returns a [*unix.Stat_t].
+func fstatatInfo(dirfd int, name string, follow bool) (fs.FileInfo, error) {
+	flags := 0
+	if !follow {
+		flags = unix.AT_SYMLINK_NOFOLLOW
+	}
+	var st unix.Stat_t
+	var err error
+	for {
+		err = unix.Fstatat(dirfd, name, &st, flags)
+		if err != unix.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fstatatFileInfo{hostFlavor.base(name), &st}, nil
+}
+
+type fstatatFileInfo struct {
+	name string
+	sys  *unix.Stat_t
+}
+
+func (i fstatatFileInfo) Name() string       { return i.name }
+func (i fstatatFileInfo) Size() int64        { return int64(i.sys.Size) }
+func (i fstatatFileInfo) Mode() fs.FileMode  { return statMode(uint32(i.sys.Mode)) }
+func (i fstatatFileInfo) ModTime() time.Time { return time.Unix(i.sys.Mtim.Unix()) }
+func (i fstatatFileInfo) IsDir() bool        { return i.Mode().IsDir() }
+func (i fstatatFileInfo) Sys() any           { return i.sys }
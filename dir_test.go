@@ -217,25 +217,27 @@ func TestDir_ExpandUser(t *testing.T) {
 	}
 }
 func TestDir_badStat(t *testing.T) {
-	temp := pathlib.Dir(t.TempDir())
-	defer func() { expect(temp.RemoveAll()) }()
-
-	expect(temp.Join("file.txt").AsFile().Make(0666))
-	expect(temp.Join("link").AsSymlink().LinkTo(temp.Join("file.txt")))
-	_, err := temp.Join("link").AsDir().Stat()
-	if _, ok := err.(pathlib.WrongTypeOnDisk[pathlib.Dir]); !ok {
-		t.Fail()
-	}
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		defer func() { expect(temp.RemoveAll()) }()
+
+		expect(temp.Join("file.txt").AsFile().Make(0666))
+		expect(temp.Join("link").AsSymlink().LinkTo(temp.Join("file.txt")))
+		_, err := temp.Join("link").AsDir().Stat()
+		if _, ok := err.(pathlib.WrongTypeOnDisk[pathlib.Dir]); !ok {
+			t.Fail()
+		}
+	})
 }
 
 func TestDir_remove(t *testing.T) {
-	temp := pathlib.Dir(t.TempDir())
-	dir := expect(temp.Join("foo").AsDir().Make(0777))
-	renamed := expect(dir.Rename(temp.Join("bar")))
-	if dir.Exists() {
-		t.Fail()
-	}
-	enforce(renamed.Remove())
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		dir := expect(temp.Join("foo").AsDir().Make(0777))
+		renamed := expect(dir.Rename(temp.Join("bar")))
+		if dir.Exists() {
+			t.Fail()
+		}
+		enforce(renamed.Remove())
+	})
 }
 
 func testChmod[P interface {
@@ -0,0 +1,284 @@
+package pathlib
+
+import (
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// A directory entry yielded by [Dir.Entries], along with its full path. Copies of an
+// Entry share its cached [Info].
+//
+// An Entry yielded alongside an error may have a nil DirEntry. Its methods are still
+// safe to call: its [EntryKind] is UnknownKind and [Entry.Lstat] observes its path.
+type Entry struct {
+	fs.DirEntry
+	path  PathStr
+	cache *entryCache
+}
+
+type entryCache struct {
+	once sync.Once
+	info Info[PathStr]
+	err  error
+}
+
+// Wraps an entry from [Dir.Read] or [Dir.Walk], whose path is path.
+func NewEntry(path PathStr, entry fs.DirEntry) Entry {
+	return Entry{entry, path, &entryCache{}}
+}
+
+// Returns the entry's path: the directory joined with the entry's name.
+func (e Entry) Path() PathStr {
+	return e.path
+}
+
+// Name implements [fs.DirEntry], falling back to the path's base name.
+func (e Entry) Name() string {
+	if e.DirEntry == nil {
+		return e.path.BaseName()
+	}
+	return e.DirEntry.Name()
+}
+
+// IsDir implements [fs.DirEntry].
+func (e Entry) IsDir() bool {
+	return e.DirEntry != nil && e.DirEntry.IsDir()
+}
+
+// Type implements [fs.DirEntry]. Without a DirEntry, the type is [fs.ModeIrregular].
+func (e Entry) Type() fs.FileMode {
+	if e.DirEntry == nil {
+		return fs.ModeIrregular
+	}
+	return e.DirEntry.Type()
+}
+
+// Info implements [fs.DirEntry]. See [Entry.Lstat].
+func (e Entry) Info() (fs.FileInfo, error) {
+	info, err := e.Lstat()
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Returns the entry's own info without following symlinks, observing it on the
+// first call and caching the result. See [fs.DirEntry.Info].
+func (e Entry) Lstat() (Info[PathStr], error) {
+	if e.cache == nil {
+		return lstat(e.path)
+	}
+	e.cache.once.Do(func() {
+		if e.DirEntry == nil {
+			e.cache.info, e.cache.err = lstat(e.path)
+			return
+		}
+		info, err := e.DirEntry.Info()
+		if err != nil {
+			e.cache.err = err
+			return
+		}
+		e.cache.info = onDisk[PathStr]{e.path, info}
+	})
+	return e.cache.info, e.cache.err
+}
+
+// What sort of filesystem object an [Entry] is.
+type EntryKind int
+
+const (
+	// A regular file.
+	FileKind EntryKind = iota
+	// A directory.
+	DirKind
+	// A symlink, which is never followed.
+	SymlinkKind
+	// A named pipe, socket, device or other irregular file.
+	OtherKind
+	// The entry was yielded with an error and has no DirEntry.
+	UnknownKind
+)
+
+func (k EntryKind) String() string {
+	switch k {
+	case FileKind:
+		return "file"
+	case DirKind:
+		return "dir"
+	case SymlinkKind:
+		return "symlink"
+	case UnknownKind:
+		return "unknown"
+	}
+	return "other"
+}
+
+// Returns the entry's kind based on [fs.DirEntry.Type], which usually doesn't need a
+// syscall.
+func (e Entry) Kind() EntryKind {
+	if e.DirEntry == nil {
+		return UnknownKind
+	}
+	t := e.Type()
+	switch {
+	case t&fs.ModeSymlink != 0:
+		return SymlinkKind
+	case t.IsDir():
+		return DirKind
+	case t.IsRegular():
+		return FileKind
+	}
+	return OtherKind
+}
+
+// Returns the entry's path as a [Dir] if it is one.
+func (e Entry) Dir() (Dir, bool) {
+	return Dir(e.path), e.Kind() == DirKind
+}
+
+// Returns the entry's path as a [File] if it is one.
+func (e Entry) File() (File, bool) {
+	return File(e.path), e.Kind() == FileKind
+}
+
+// Returns the entry's path as a [Symlink] if it is one.
+func (e Entry) Symlink() (Symlink, bool) {
+	return Symlink(e.path), e.Kind() == SymlinkKind
+}
+
+// Handles each [EntryKind]. See [Entry.Visit].
+type EntryVisitor interface {
+	OnDir(dir Dir) error
+	OnFile(file File) error
+	OnSymlink(link Symlink) error
+	// Called for named pipes, sockets, devices and other irregular files, with the
+	// entry's type bits, and for entries of UnknownKind.
+	OnOther(path PathStr, mode fs.FileMode) error
+}
+
+// Calls the visitor's method for the entry's kind with its typed path.
+func (e Entry) Visit(v EntryVisitor) error {
+	switch e.Kind() {
+	case DirKind:
+		return v.OnDir(Dir(e.path))
+	case FileKind:
+		return v.OnFile(File(e.path))
+	case SymlinkKind:
+		return v.OnSymlink(Symlink(e.path))
+	}
+	return v.OnOther(e.path, e.Type())
+}
+
+// Options that control which entries [Dir.Entries] yields, and in what order.
+type EntriesOptions struct {
+	// The number of entries to read from the directory at a time. Zero or less
+	// means 256.
+	BatchSize int
+	// Yield entries sorted by name. This reads the whole directory before
+	// yielding anything, like [Dir.Read].
+	Sorted bool
+	// Skip entries whose names start with a ".".
+	SkipHidden bool
+	// If non-empty, only yield entries whose names match the patterns.
+	Names Patterns
+}
+
+const defaultBatchSize = 256
+
+// implemented by open files that can list a directory in batches, like [os.File].
+type dirReader interface {
+	ReadDir(n int) ([]fs.DirEntry, error)
+}
+
+// Lazily yields the directory's entries in the order the filesystem returns them,
+// reading opts.BatchSize entries at a time instead of the whole directory. On
+// backends whose open files can't read a directory in batches, the whole directory is
+// read at once.
+//
+// An error opening or reading the directory is yielded with an Entry of UnknownKind
+// whose path is the directory, and ends the iteration. Breaking out of the loop closes the directory.
+func (d Dir) Entries(opts EntriesOptions) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		keep := func(entry fs.DirEntry) bool {
+			name := entry.Name()
+			if opts.SkipHidden && strings.HasPrefix(name, ".") {
+				return false
+			}
+			return len(opts.Names) == 0 || opts.Names.Match(PathStr(name))
+		}
+		batches := d.batches(opts.BatchSize)
+		if opts.Sorted {
+			var all []fs.DirEntry
+			for batch, err := range batches {
+				if err != nil {
+					yield(Entry{path: PathStr(d)}, err)
+					return
+				}
+				all = append(all, batch...)
+			}
+			slices.SortFunc(all, func(a, b fs.DirEntry) int {
+				return strings.Compare(a.Name(), b.Name())
+			})
+			batches = func(yield func([]fs.DirEntry, error) bool) { yield(all, nil) }
+		}
+		for batch, err := range batches {
+			if err != nil {
+				yield(Entry{path: PathStr(d)}, err)
+				return
+			}
+			for _, entry := range batch {
+				if keep(entry) && !yield(NewEntry(d.Join(entry.Name()), entry), nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Like [Dir.Read], but returns each entry as an [Entry] with its full path.
+func (d Dir) ReadEntries() ([]Entry, error) {
+	dirEntries, err := d.Read()
+	entries := make([]Entry, len(dirEntries))
+	for i, entry := range dirEntries {
+		entries[i] = NewEntry(d.Join(entry.Name()), entry)
+	}
+	return entries, err
+}
+
+// yields the directory's entries in batches of up to n.
+func (d Dir) batches(n int) iter.Seq2[[]fs.DirEntry, error] {
+	if n <= 0 {
+		n = defaultBatchSize
+	}
+	return func(yield func([]fs.DirEntry, error) bool) {
+		f, err := openFile(d, os.O_RDONLY, 0)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer f.Close()
+		r, ok := f.RawFile.(dirReader)
+		if !ok {
+			entries, err := d.Read()
+			yield(entries, err)
+			return
+		}
+		for {
+			batch, err := r.ReadDir(n)
+			if len(batch) > 0 && !yield(batch, nil) {
+				return
+			}
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDir_Entries() {
+	dir := pathlib.Dir("/example-entries")
+	defer pathlib.Bind(dir, pathlib.NewMemFS())()
+	expect(dir.MakeAll(0o755, 0o755))
+	for _, name := range []string{"b.json", ".hidden.json", "a.json", "c.txt"} {
+		expect(dir.Join(name).AsFile().Make(0o644)).Close()
+	}
+	opts := pathlib.EntriesOptions{
+		Sorted:     true,
+		SkipHidden: true,
+		Names:      expect(pathlib.CompilePatterns("*.json")),
+	}
+	for entry, err := range dir.Entries(opts) {
+		enforce(err)
+		fmt.Println(entry.Name(), entry.Path().BaseName() == entry.Name())
+	}
+	// Output:
+	// a.json true
+	// b.json true
+}
+
+func TestDir_Entries(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		var expected []string
+		for i := range 10 {
+			name := fmt.Sprintf("file%d", i)
+			writeFile(t, temp.Join(name).AsFile(), "")
+			expected = append(expected, name)
+		}
+		expect(temp.Join("sub").AsDir().Make(0o755))
+		writeFile(t, temp.Join(".hidden").AsFile(), "")
+		expected = append(expected, ".hidden", "sub")
+		slices.Sort(expected)
+
+		list := func(opts pathlib.EntriesOptions) (result []string) {
+			t.Helper()
+			for entry, err := range temp.Entries(opts) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if entry.Path() != temp.Join(entry.Name()) {
+					t.Errorf("unexpected path %q for %q", entry.Path(), entry.Name())
+				}
+				result = append(result, entry.Name())
+			}
+			return
+		}
+
+		unsorted := list(pathlib.EntriesOptions{BatchSize: 3})
+		slices.Sort(unsorted)
+		if !slices.Equal(unsorted, expected) {
+			t.Errorf("expected %q, got %q", expected, unsorted)
+		}
+		if sorted := list(pathlib.EntriesOptions{BatchSize: 3, Sorted: true}); !slices.Equal(sorted, expected) {
+			t.Errorf("expected %q, got %q", expected, sorted)
+		}
+		if visible := list(pathlib.EntriesOptions{Sorted: true, SkipHidden: true}); !slices.Equal(visible, expected[1:]) {
+			t.Errorf("expected %q, got %q", expected[1:], visible)
+		}
+		names := expect(pathlib.CompilePatterns("file*", "!file[3-9]", "sub"))
+		if matched := list(pathlib.EntriesOptions{Sorted: true, Names: names}); !slices.Equal(matched, []string{"file0", "file1", "file2", "sub"}) {
+			t.Errorf("unexpected matches %q", matched)
+		}
+
+		count := 0
+		for range temp.Entries(pathlib.EntriesOptions{BatchSize: 2}) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("expected to stop after 3 entries, got %d", count)
+		}
+
+		for _, d := range []pathlib.Dir{temp.Join("missing").AsDir(), temp.Join("file0").AsDir()} {
+			var errs []error
+			for entry, err := range d.Entries(pathlib.EntriesOptions{}) {
+				if entry.DirEntry != nil {
+					t.Errorf("unexpected entry %q", entry.Name())
+				}
+				errs = append(errs, err)
+			}
+			if len(errs) != 1 || errs[0] == nil {
+				t.Errorf("%q: expected a single error, got %v", d, errs)
+			}
+		}
+		for _, err := range temp.Join("missing").AsDir().Entries(pathlib.EntriesOptions{Sorted: true}) {
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("expected ErrNotExist, got %v", err)
+			}
+		}
+	})
+}
+
+// records each visit as "kind:name".
+type recordingVisitor struct{ visits []string }
+
+func (v *recordingVisitor) OnDir(dir pathlib.Dir) error {
+	v.visits = append(v.visits, "dir:"+dir.BaseName())
+	return nil
+}
+
+func (v *recordingVisitor) OnFile(file pathlib.File) error {
+	v.visits = append(v.visits, "file:"+file.BaseName())
+	return nil
+}
+
+func (v *recordingVisitor) OnSymlink(link pathlib.Symlink) error {
+	v.visits = append(v.visits, "symlink:"+link.BaseName())
+	return nil
+}
+
+func (v *recordingVisitor) OnOther(path pathlib.PathStr, mode fs.FileMode) error {
+	v.visits = append(v.visits, fmt.Sprintf("other:%s:%v", path.BaseName(), mode.Type()))
+	return nil
+}
+
+// lists the directory's entries by visiting them in name order.
+func visitEntries(t *testing.T, dir pathlib.Dir) []string {
+	t.Helper()
+	var v recordingVisitor
+	for entry, err := range dir.Entries(pathlib.EntriesOptions{Sorted: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		enforce(entry.Visit(&v))
+	}
+	return v.visits
+}
+
+func TestEntry_kinds(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("file").AsFile(), "content")
+		expect(temp.Join("dir").AsDir().Make(0o755))
+		expect(temp.Join("link").AsSymlink().LinkTo("dir"))
+
+		expected := []string{"dir:dir", "file:file", "symlink:link"}
+		if visits := visitEntries(t, temp); !slices.Equal(visits, expected) {
+			t.Errorf("expected %q, got %q", expected, visits)
+		}
+
+		for entry, err := range temp.Entries(pathlib.EntriesOptions{}) {
+			enforce(err)
+			dir, isDir := entry.Dir()
+			file, isFile := entry.File()
+			link, isLink := entry.Symlink()
+			switch entry.Name() {
+			case "dir":
+				if entry.Kind() != pathlib.DirKind || !isDir || isFile || isLink || dir != temp.Join("dir").AsDir() {
+					t.Errorf("unexpected dir entry: %v", entry.Kind())
+				}
+			case "file":
+				if entry.Kind() != pathlib.FileKind || isDir || !isFile || isLink || file != temp.Join("file").AsFile() {
+					t.Errorf("unexpected file entry: %v", entry.Kind())
+				}
+			case "link":
+				if entry.Kind() != pathlib.SymlinkKind || isDir || isFile || !isLink || link != temp.Join("link").AsSymlink() {
+					t.Errorf("unexpected symlink entry: %v", entry.Kind())
+				}
+			}
+		}
+	})
+}
+
+func TestEntry_Lstat(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("file").AsFile(), "content")
+		for entry, err := range temp.Entries(pathlib.EntriesOptions{}) {
+			enforce(err)
+			info := expect(entry.Lstat())
+			if info.Path() != temp.Join("file") || info.Size() != 7 {
+				t.Errorf("unexpected info %q, size %d", info.Path(), info.Size())
+			}
+			enforce(temp.Join("file").Remove())
+			copied := entry
+			if cached := expect(copied.Lstat()); cached != info {
+				t.Error("expected copies to share the cached info")
+			}
+		}
+	})
+}
+
+func TestNewEntry(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	writeFile(t, temp.Join("a", "b").AsFile(), "")
+	var visits recordingVisitor
+	enforce(temp.Walk(func(path pathlib.PathStr, d fs.DirEntry, err error) error {
+		if err != nil || path == pathlib.PathStr(temp) {
+			return err
+		}
+		return pathlib.NewEntry(path, d).Visit(&visits)
+	}))
+	if expected := []string{"dir:a", "file:b"}; !slices.Equal(visits.visits, expected) {
+		t.Errorf("expected %q, got %q", expected, visits.visits)
+	}
+}
+
+func TestDir_ReadEntries(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("b").AsFile(), "")
+		expect(temp.Join("a").AsDir().Make(0o755))
+		var visits recordingVisitor
+		for _, entry := range expect(temp.ReadEntries()) {
+			if entry.Path() != temp.Join(entry.Name()) {
+				t.Errorf("unexpected path %q for %q", entry.Path(), entry.Name())
+			}
+			enforce(entry.Visit(&visits))
+		}
+		if expected := []string{"dir:a", "file:b"}; !slices.Equal(visits.visits, expected) {
+			t.Errorf("expected %q, got %q", expected, visits.visits)
+		}
+		if _, err := temp.Join("missing").AsDir().ReadEntries(); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestEntry_zero(t *testing.T) {
+	missing := pathlib.Dir(t.TempDir()).Join("missing").AsDir()
+	var entries []pathlib.Entry
+	for entry, err := range missing.Entries(pathlib.EntriesOptions{}) {
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 1 || entries[0].Path() != pathlib.PathStr(missing) {
+		t.Fatalf("expected one entry for the directory, got %#v", entries)
+	}
+
+	for _, entry := range []pathlib.Entry{entries[0], {}} {
+		if kind := entry.Kind(); kind != pathlib.UnknownKind {
+			t.Errorf("expected UnknownKind, got %v", kind)
+		}
+		if entry.IsDir() || entry.Type() != fs.ModeIrregular {
+			t.Errorf("unexpected type %v", entry.Type())
+		}
+		if _, err := entry.Lstat(); err == nil {
+			t.Error("expected an error observing a missing entry")
+		}
+		if _, err := entry.Info(); err == nil {
+			t.Error("expected an error from Info")
+		}
+		var visits recordingVisitor
+		enforce(entry.Visit(&visits))
+		if len(visits.visits) != 1 {
+			t.Errorf("expected one visit, got %q", visits.visits)
+		}
+	}
+	if name := entries[0].Name(); name != "missing" {
+		t.Errorf("expected the name to fall back to the base name, got %q", name)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package pathlib_test
+
+import (
+	"slices"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func TestEntry_other(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	enforce(unix.Mkfifo(temp.Join("fifo").String(), 0o644))
+	for entry, err := range temp.Entries(pathlib.EntriesOptions{}) {
+		enforce(err)
+		if entry.Kind() != pathlib.OtherKind || entry.Kind().String() != "other" {
+			t.Errorf("expected a fifo to be %v, got %v", pathlib.OtherKind, entry.Kind())
+		}
+		if _, ok := entry.File(); ok {
+			t.Error("expected a fifo not to be a File")
+		}
+	}
+	if visits := visitEntries(t, temp); !slices.Equal(visits, []string{"other:fifo:p---------"}) {
+		t.Errorf("unexpected visits %q", visits)
+	}
+}
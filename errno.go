@@ -0,0 +1,14 @@
+//go:build !plan9
+
+package pathlib
+
+import "syscall"
+
+// errnos that package syscall doesn't define on every platform.
+const (
+	errCrossDevice  = syscall.EXDEV
+	errLoop         = syscall.ELOOP
+	errNotEmpty     = syscall.ENOTEMPTY
+	errNotSupported = syscall.ENOTSUP
+	errBadFD        = syscall.EBADF
+)
@@ -0,0 +1,12 @@
+package pathlib
+
+import "syscall"
+
+// Plan 9 has no errnos, so these mirror the ones package syscall invents for it.
+var (
+	errCrossDevice  = syscall.NewError("cross-device link")
+	errLoop         = syscall.NewError("too many levels of symbolic links")
+	errNotEmpty     = syscall.NewError("directory not empty")
+	errNotSupported = syscall.NewError("operation not supported")
+	errBadFD        = syscall.NewError("bad file descriptor")
+)
@@ -0,0 +1,11 @@
+package pathlib_test
+
+import "syscall"
+
+// the errors pathlib reports in place of the errnos Plan 9 lacks.
+var (
+	errCrossDevice = syscall.NewError("cross-device link")
+	errLoop        = syscall.NewError("too many levels of symbolic links")
+	errNotEmpty    = syscall.NewError("directory not empty")
+	errOpNotSupp   = syscall.EPLAN9
+)
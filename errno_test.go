@@ -0,0 +1,13 @@
+//go:build !plan9
+
+package pathlib_test
+
+import "syscall"
+
+// errnos that package syscall doesn't define on every platform.
+const (
+	errCrossDevice = syscall.EXDEV
+	errLoop        = syscall.ELOOP
+	errNotEmpty    = syscall.ENOTEMPTY
+	errOpNotSupp   = syscall.EOPNOTSUPP
+)
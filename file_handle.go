@@ -1,16 +1,19 @@
 package pathlib
 
 import (
+	"context"
 	"errors"
 	"io"
 	"io/fs"
+	"iter"
 	"os"
 	"syscall"
 	"time"
 )
 
 // An open file descriptor. Unlike an [os.File], it can only represent a logical
-// file (as in a document on-disk), never a directory.
+// file (as in a document on-disk), never a directory. Handles also implement
+// [io.ReaderAt] and [io.WriterAt].
 type FileHandle interface {
 	Path() File
 	PurePath
@@ -19,6 +22,46 @@ type FileHandle interface {
 	Changer
 	Remover[File]
 
+	// Returns the identity of the open file, which stays the same if it is renamed.
+	ID() (FileID, error)
+
+	// Lazily yield each line from the current offset. See [File.Lines].
+	Lines(opts LineOptions) iter.Seq2[string, error]
+
+	// Acquire an advisory flock(2) lock on the whole file, blocking until no other open
+	// file description holds a conflicting lock. Exclusive locks conflict with every
+	// other lock; shared locks only conflict with exclusive ones. Locking again
+	// converts the lock rather than stacking it.
+	//
+	// On Windows, this is a LockFileEx lock, which is mandatory: while it's held, other
+	// handles can't write the file, or read it if the lock is exclusive. Other
+	// platforms without flock(2) return [errors.ErrUnsupported].
+	Lock(exclusive bool) error
+	// Like Lock, but returns false instead of blocking.
+	TryLock(exclusive bool) (bool, error)
+	// Like Lock, but gives up with ctx's error when ctx is done. While the lock is
+	// contended, it retries with exponential backoff.
+	LockContext(ctx context.Context, exclusive bool) error
+	// Release the lock taken by Lock. Closing the handle also releases it.
+	Unlock() error
+	// Acquire an advisory fcntl(2) open file description lock on length bytes from
+	// offset, or to the end of the file if length is 0. These locks don't interact
+	// with Lock. Only supported on Linux.
+	LockRange(offset, length int64, exclusive bool) error
+	// Like LockRange, but returns false instead of blocking.
+	TryLockRange(offset, length int64, exclusive bool) (bool, error)
+	// Like LockRange, but gives up with ctx's error when ctx is done.
+	LockRangeContext(ctx context.Context, offset, length int64, exclusive bool) error
+	// Release the given bytes, which may split a locked range in two.
+	UnlockRange(offset, length int64) error
+
+	// Extended attributes, using fgetxattr(2) and friends. See [PathStr.GetXattr].
+	GetXattr(name string) ([]byte, error)
+	SetXattr(name string, value []byte) error
+	ListXattr() ([]string, error)
+	RemoveXattr(name string) error
+	Xattrs() (map[string][]byte, error)
+
 	// from *os.File
 	Name() string
 	Truncate(size int64) error
@@ -35,7 +78,7 @@ type FileHandle interface {
 	io.StringWriter
 }
 
-type handle struct{ *os.File }
+type handle struct{ RawFile }
 
 var _ FileHandle = &handle{}
 
@@ -52,6 +95,7 @@ func (h *handle) String() string {
 
 // PurePath --------------------------------------------------------------------
 var _ PurePath = &handle{}
+var _ Stemmer = &handle{}
 
 // BaseName implements [PurePath].
 func (h *handle) BaseName() string {
@@ -88,6 +132,21 @@ func (h *handle) Parts() []string {
 	return h.Path().Parts()
 }
 
+// Stem implements [Stemmer].
+func (h *handle) Stem() string {
+	return h.Path().Stem()
+}
+
+// Suffixes implements [Stemmer].
+func (h *handle) Suffixes() []string {
+	return h.Path().Suffixes()
+}
+
+// IsRelativeTo implements [PureTransformer].
+func (h *handle) IsRelativeTo(base Dir) bool {
+	return h.Path().IsRelativeTo(base)
+}
+
 // Beholder --------------------------------------------------------------------
 
 var _ Beholder[File] = &handle{}
@@ -118,7 +177,7 @@ func (h *handle) closeIfNonexistent(err error) {
 // Stat implements [Beholder]
 func (h *handle) Stat() (Info[File], error) {
 	info, err := h.Path().Stat()
-	// it might be cheaper to use the `h.File.Stat()` method, but that
+	// it might be cheaper to use the `h.RawFile.Stat()` method, but that
 	// seems to erroneously report that the file exists if the file has
 	// been removed since the handle was opened.
 	h.closeIfNonexistent(err)
@@ -136,19 +195,33 @@ func (h *handle) Exists() bool {
 
 // Changer ---------------------------------------------------------------------
 var _ Changer = &handle{}
+var _ TimeChanger = &handle{}
 
 // See [os.Chmod].
 //
 // Chmod implements [Changer].
 func (h *handle) Chmod(mode fs.FileMode) error {
-	return h.File.Chmod(mode)
+	return h.RawFile.Chmod(mode)
 }
 
 // See [os.Chown].
 //
 // Chown implements [Changer].
 func (h *handle) Chown(uid int, gid int) error {
-	return h.File.Chown(uid, gid)
+	return h.RawFile.Chown(uid, gid)
+}
+
+// Uses the file descriptor where possible, so it works after the file is renamed.
+//
+// Chtimes implements [TimeChanger].
+func (h *handle) Chtimes(atime, mtime time.Time) error {
+	if f, ok := h.RawFile.(*os.File); ok {
+		err := futimes(f, atime, mtime)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return err
+		}
+	}
+	return chtimes(h.Path(), atime, mtime, true)
 }
 
 // Mover -----------------------------------------------------------------------
@@ -175,6 +248,7 @@ func (h *handle) Rename(newPath PathStr) (File, error) {
 
 // Transformer ------------------------------------------------------------------
 var _ Transformer[File] = &handle{}
+var _ PureTransformer[File] = &handle{}
 
 // Returns an absolute path, or an error if the path cannot be made absolute. Note that there may be more than one
 // absolute path for a given input path.
@@ -216,3 +290,31 @@ func (h *handle) Localize() (File, error) {
 func (h *handle) Rel(base Dir) (File, error) {
 	return h.Path().Rel(base)
 }
+
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (h *handle) WithName(name string) (File, error) {
+	return h.Path().WithName(name)
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (h *handle) WithStem(stem string) (File, error) {
+	return h.Path().WithStem(stem)
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (h *handle) WithSuffix(suffix string) (File, error) {
+	return h.Path().WithSuffix(suffix)
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (h *handle) RelativeTo(base Dir) (File, error) {
+	return h.Path().RelativeTo(base)
+}
@@ -233,3 +233,34 @@ func TestFileHandle_openSymlink(t *testing.T) {
 		t.Fatalf("expected %dB, got %dB", len(content), info.Size())
 	}
 }
+
+func TestFileHandle_at(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := writeFile(t, temp.Join("file").AsFile(), "hello world")
+		h := expect(file.Open(os.O_RDWR, 0))
+		defer func() { enforce(h.Close()) }()
+
+		w, ok := h.(io.WriterAt)
+		if !ok {
+			t.Fatal("expected handles to implement io.WriterAt")
+		}
+		expect(w.WriteAt([]byte("WORLD!"), 6))
+		r, ok := h.(io.ReaderAt)
+		if !ok {
+			t.Fatal("expected handles to implement io.ReaderAt")
+		}
+		buf := make([]byte, 5)
+		if n, err := r.ReadAt(buf, 8); n != 4 || err != io.EOF || string(buf[:n]) != "RLD!" {
+			t.Errorf("unexpected ReadAt result %d %v %q", n, err, buf[:n])
+		}
+		if offset := expect(h.Seek(0, io.SeekCurrent)); offset != 0 {
+			t.Errorf("expected ReadAt and WriteAt to leave the offset alone, got %d", offset)
+		}
+
+		appending := expect(file.Open(os.O_WRONLY|os.O_APPEND, 0))
+		defer func() { enforce(appending.Close()) }()
+		if _, err := appending.(io.WriterAt).WriteAt([]byte("x"), 0); err == nil {
+			t.Error("expected WriteAt to fail on a file opened with O_APPEND")
+		}
+	})
+}
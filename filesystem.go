@@ -0,0 +1,227 @@
+package pathlib
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// A backend that performs the I/O behind path methods. Every method receives the
+// untyped path string as written by the caller.
+//
+// The default backend is [OS]. Use [Bind] to route paths to another backend.
+type Filesystem interface {
+	// See [os.Stat].
+	Stat(name string) (fs.FileInfo, error)
+	// See [os.Lstat].
+	Lstat(name string) (fs.FileInfo, error)
+	// See [os.OpenFile].
+	OpenFile(name string, flag int, perm fs.FileMode) (RawFile, error)
+	// See [os.ReadFile].
+	ReadFile(name string) ([]byte, error)
+	// See [os.ReadDir].
+	ReadDir(name string) ([]fs.DirEntry, error)
+	// See [os.Mkdir].
+	Mkdir(name string, perm fs.FileMode) error
+	// See [os.MkdirAll].
+	MkdirAll(name string, perm fs.FileMode) error
+	// See [os.Remove].
+	Remove(name string) error
+	// See [os.RemoveAll].
+	RemoveAll(name string) error
+	// See [os.Rename].
+	Rename(oldName, newName string) error
+	// See [os.Chmod].
+	Chmod(name string, mode fs.FileMode) error
+	// See [os.Chown].
+	Chown(name string, uid, gid int) error
+	// See [os.Lchown].
+	Lchown(name string, uid, gid int) error
+	// See [os.Chtimes].
+	Chtimes(name string, atime, mtime time.Time) error
+	// See [os.Symlink].
+	Symlink(target, name string) error
+	// See [os.Readlink].
+	Readlink(name string) (string, error)
+}
+
+// The subset of [*os.File]'s methods that a [Filesystem] must provide for an open file.
+type RawFile interface {
+	Name() string
+	Stat() (fs.FileInfo, error)
+	Sync() error
+	Truncate(size int64) error
+	Chmod(mode fs.FileMode) error
+	Chown(uid, gid int) error
+	SyscallConn() (syscall.RawConn, error)
+	SetDeadline(deadline time.Time) error
+	SetReadDeadline(deadline time.Time) error
+	SetWriteDeadline(deadline time.Time) error
+	Fd() uintptr
+
+	io.Closer
+	io.Seeker
+	io.Reader
+	io.ReaderAt
+	io.Writer
+	io.WriterAt
+	io.StringWriter
+}
+
+var _ RawFile = (*os.File)(nil)
+
+// The default [Filesystem]: a thin wrapper around package [os].
+type OS struct{}
+
+var _ Filesystem = OS{}
+
+// Stat implements [Filesystem].
+func (OS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+// Lstat implements [Filesystem].
+func (OS) Lstat(name string) (fs.FileInfo, error) { return os.Lstat(name) }
+
+// OpenFile implements [Filesystem].
+func (OS) OpenFile(name string, flag int, perm fs.FileMode) (RawFile, error) {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		// avoid returning a non-nil interface wrapping a nil *os.File
+		return nil, err
+	}
+	return f, nil
+}
+
+// ReadFile implements [Filesystem].
+func (OS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+// ReadDir implements [Filesystem].
+func (OS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+// Mkdir implements [Filesystem].
+func (OS) Mkdir(name string, perm fs.FileMode) error { return os.Mkdir(name, perm) }
+
+// MkdirAll implements [Filesystem].
+func (OS) MkdirAll(name string, perm fs.FileMode) error { return os.MkdirAll(name, perm) }
+
+// Remove implements [Filesystem].
+func (OS) Remove(name string) error { return os.Remove(name) }
+
+// RemoveAll implements [Filesystem].
+func (OS) RemoveAll(name string) error { return os.RemoveAll(name) }
+
+// Rename implements [Filesystem].
+func (OS) Rename(oldName, newName string) error { return os.Rename(oldName, newName) }
+
+// Chmod implements [Filesystem].
+func (OS) Chmod(name string, mode fs.FileMode) error { return os.Chmod(name, mode) }
+
+// Chown implements [Filesystem].
+func (OS) Chown(name string, uid, gid int) error { return os.Chown(name, uid, gid) }
+
+// Lchown implements [Filesystem].
+func (OS) Lchown(name string, uid, gid int) error { return os.Lchown(name, uid, gid) }
+
+// Chtimes implements [Filesystem].
+func (OS) Chtimes(name string, atime, mtime time.Time) error { return os.Chtimes(name, atime, mtime) }
+
+// Symlink implements [Filesystem].
+func (OS) Symlink(target, name string) error { return os.Symlink(target, name) }
+
+// Readlink implements [Filesystem].
+func (OS) Readlink(name string) (string, error) { return os.Readlink(name) }
+
+// bindings ---------------------------------------------------------------------
+
+type binding struct {
+	prefix string // cleaned, absolute
+	fsys   Filesystem
+}
+
+var (
+	bindingsMu sync.RWMutex
+	bindings   []*binding
+)
+
+// Route every path at or beneath prefix through fsys instead of [OS]. Backends
+// receive paths unchanged; the prefix is not stripped. When bindings overlap, the
+// longest prefix wins, and among equal prefixes the most recent binding wins.
+//
+// Relative paths are matched against bindings after being made absolute with
+// [path/filepath.Abs], but are passed to the backend unchanged, so backends must
+// resolve them against the working directory, as [OS] and [MemFS] do.
+//
+// Bind returns a function that removes the binding. It is safe to call more than once.
+func Bind(prefix Dir, fsys Filesystem) (unbind func()) {
+	b := &binding{prefix: bindingKey(string(prefix)), fsys: fsys}
+	bindingsMu.Lock()
+	bindings = append(bindings, b)
+	bindingsMu.Unlock()
+	return func() {
+		bindingsMu.Lock()
+		defer bindingsMu.Unlock()
+		bindings = slices.DeleteFunc(bindings, func(c *binding) bool { return c == b })
+	}
+}
+
+// Returns the [Filesystem] that operations on the given path dispatch to.
+func FilesystemOf[P Kind](p P) Filesystem {
+	return filesystemOf(string(p))
+}
+
+func filesystemOf(name string) Filesystem {
+	if b := bindingOf(name); b != nil {
+		return b.fsys
+	}
+	return OS{}
+}
+
+// Returns true if both paths dispatch to the same binding, so that an operation on
+// both stays within one backend. Bindings are compared instead of [Filesystem]
+// values, which might not be comparable.
+func sameBinding(a, b string) bool {
+	return bindingOf(a) == bindingOf(b)
+}
+
+// returns the binding with the longest prefix of the path, or nil if there is none.
+func bindingOf(name string) *binding {
+	bindingsMu.RLock()
+	defer bindingsMu.RUnlock()
+	if len(bindings) == 0 {
+		return nil
+	}
+	key := bindingKey(name)
+	var best *binding
+	for _, b := range bindings {
+		if !hasPathPrefix(key, b.prefix) {
+			continue
+		}
+		if best == nil || len(b.prefix) >= len(best.prefix) {
+			best = b
+		}
+	}
+	return best
+}
+
+func bindingKey(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
+
+// reports whether the cleaned path p is prefix or a descendant of prefix.
+func hasPathPrefix(p, prefix string) bool {
+	if !strings.HasPrefix(p, prefix) {
+		return false
+	}
+	if len(p) == len(prefix) || os.IsPathSeparator(prefix[len(prefix)-1]) {
+		return true
+	}
+	return os.IsPathSeparator(p[len(prefix)])
+}
@@ -0,0 +1,118 @@
+package pathlib_test
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// a Filesystem that records the operations performed on it before delegating to the OS.
+type recordingFS struct {
+	pathlib.OS
+	ops *[]string
+}
+
+func (r recordingFS) record(op, name string) { *r.ops = append(*r.ops, op+" "+name) }
+
+func (r recordingFS) Stat(name string) (fs.FileInfo, error) {
+	r.record("stat", name)
+	return r.OS.Stat(name)
+}
+
+func (r recordingFS) Mkdir(name string, perm fs.FileMode) error {
+	r.record("mkdir", name)
+	return r.OS.Mkdir(name, perm)
+}
+
+func (r recordingFS) OpenFile(name string, flag int, perm fs.FileMode) (pathlib.RawFile, error) {
+	r.record("open", name)
+	return r.OS.OpenFile(name, flag, perm)
+}
+
+func TestBind(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	var ops []string
+	unbind := pathlib.Bind(temp.Join("bound").AsDir(), recordingFS{ops: &ops})
+	defer unbind()
+
+	dir := expect(temp.Join("bound").AsDir().Make(0o755))
+	enforce(expect(dir.Join("file.txt").AsFile().Make(0o644)).Close())
+	expect(dir.Stat())
+	expect(temp.Join("unbound").AsDir().Make(0o755))
+
+	expected := []string{
+		"mkdir " + dir.String(),
+		"open " + dir.Join("file.txt").String(),
+		"stat " + dir.String(),
+	}
+	if !slices.Equal(ops, expected) {
+		t.Fatalf("expected %q\ngot %q", expected, ops)
+	}
+
+	unbind()
+	unbind() // safe to call more than once
+	expect(dir.Stat())
+	if len(ops) != len(expected) {
+		t.Fatalf("unexpected ops after unbinding: %q", ops[len(expected):])
+	}
+}
+
+func TestBind_longestPrefix(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	outer := recordingFS{ops: &[]string{}}
+	inner := recordingFS{ops: &[]string{}}
+	defer pathlib.Bind(temp.Join("a/b").AsDir(), inner)()
+	defer pathlib.Bind(temp.Join("a").AsDir(), outer)()
+
+	cases := map[pathlib.PathStr]pathlib.Filesystem{
+		temp.Join("a"):       outer,
+		temp.Join("a/bc"):    outer,
+		temp.Join("a/b"):     inner,
+		temp.Join("a/b/c/d"): inner,
+		temp.Join("ab"):      pathlib.OS{},
+	}
+	for p, fsys := range cases {
+		if actual := pathlib.FilesystemOf(p); actual != fsys {
+			t.Errorf("%s: expected %T(%p), got %T(%p)", p, fsys, fsys, actual, actual)
+		}
+	}
+}
+
+func TestBind_crossBackendRename(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	defer pathlib.Bind(temp.Join("bound").AsDir(), recordingFS{ops: &[]string{}})()
+
+	file := temp.Join("file.txt").AsFile()
+	enforce(expect(file.Make(0o644)).Close())
+	_, err := file.Rename(temp.Join("bound", "file.txt"))
+	if !errors.Is(err, errCrossDevice) {
+		t.Fatalf("expected EXDEV, got %v", err)
+	}
+	if !file.Exists() {
+		t.Fatal("failed rename should leave the source in place")
+	}
+}
+
+// a backend that can't be compared with ==.
+type uncomparableFS struct {
+	pathlib.OS
+	tags map[string]string
+}
+
+func TestBind_uncomparable(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	bound := temp.Join("bound").AsDir()
+	defer pathlib.Bind(bound, uncomparableFS{tags: map[string]string{}})()
+
+	file := writeFile(t, expect(bound.Make(0o755)).Join("a").AsFile(), "")
+	moved := expect(file.Rename(bound.Join("b")))
+	if !moved.Exists() || file.Exists() {
+		t.Errorf("expected %q to be moved to %q", file, moved)
+	}
+	if _, err := moved.Rename(temp.Join("c")); !errors.Is(err, errCrossDevice) {
+		t.Errorf("expected EXDEV, got %v", err)
+	}
+}
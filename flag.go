@@ -0,0 +1,139 @@
+package pathlib
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Options for [PathFlag], [DirFlag] and [FileFlag]. The embedded [DecodeOptions]
+// are applied first, then Abs, then MakeAll, then Stat.
+type FlagOptions struct {
+	DecodeOptions
+	// Resolve relative paths against [Cwd] when the flag is parsed. Has no effect
+	// if Base is set, since Base already makes paths absolute.
+	Abs bool
+	// Create a missing directory, or a file or path's missing parent directories, with
+	// [Dir.MakeAll] and permissions 0o777 before umask.
+	MakeAll bool
+}
+
+// parses a flag value into the typed path described by kind.
+func parseFlag[P Kind](value string, opts FlagOptions, kind string) (P, error) {
+	p, err := parseFlagPath[P](value, opts)
+	if err != nil {
+		return p, fmt.Errorf("expected %s: %w", kind, err)
+	}
+	return p, nil
+}
+
+func parseFlagPath[P Kind](value string, opts FlagOptions) (p P, err error) {
+	stat := opts.Stat
+	opts.Stat = false
+	if p, err = Decode[P](value, opts.DecodeOptions); err != nil {
+		return
+	}
+	if opts.Abs {
+		if p, err = abs(p); err != nil {
+			return
+		}
+	}
+	if opts.MakeAll {
+		dir := p.Parent()
+		if d, ok := any(p).(Dir); ok {
+			dir = d
+		}
+		if _, err = dir.MakeAll(0o777, 0o777); err != nil {
+			return
+		}
+	}
+	if stat {
+		err = checkOnDisk(p)
+	}
+	return
+}
+
+// A [flag.Value] holding a [PathStr]. With Stat set, the path must exist.
+//
+//	out := pathlib.PathFlag{Path: "out.txt"}
+//	flag.Var(&out, "out", "where to write results")
+//
+// Like other flags, the default value isn't parsed, so it isn't checked or resolved.
+type PathFlag struct {
+	Path PathStr
+	FlagOptions
+}
+
+var _ flag.Getter = &PathFlag{}
+
+// String implements [flag.Value].
+func (f *PathFlag) String() string {
+	return string(f.Path)
+}
+
+// Set implements [flag.Value].
+func (f *PathFlag) Set(value string) error {
+	p, err := parseFlag[PathStr](value, f.FlagOptions, "a path")
+	if err == nil {
+		f.Path = p
+	}
+	return err
+}
+
+// Get implements [flag.Getter].
+func (f *PathFlag) Get() any {
+	return f.Path
+}
+
+// A [flag.Value] holding a [Dir]. With Stat set, the path must be a directory; see [PathFlag].
+type DirFlag struct {
+	Path Dir
+	FlagOptions
+}
+
+var _ flag.Getter = &DirFlag{}
+
+// String implements [flag.Value].
+func (f *DirFlag) String() string {
+	return string(f.Path)
+}
+
+// Set implements [flag.Value].
+func (f *DirFlag) Set(value string) error {
+	p, err := parseFlag[Dir](value, f.FlagOptions, "a directory")
+	if err == nil {
+		f.Path = p
+	}
+	return err
+}
+
+// Get implements [flag.Getter].
+func (f *DirFlag) Get() any {
+	return f.Path
+}
+
+// A [flag.Value] holding a [File]. With Stat set, the path must be a regular file; see [PathFlag].
+type FileFlag struct {
+	Path File
+	FlagOptions
+}
+
+var _ flag.Getter = &FileFlag{}
+
+// String implements [flag.Value].
+func (f *FileFlag) String() string {
+	return string(f.Path)
+}
+
+// Set implements [flag.Value].
+func (f *FileFlag) Set(value string) error {
+	p, err := parseFlag[File](value, f.FlagOptions, "a file")
+	if err == nil {
+		f.Path = p
+	}
+	return err
+}
+
+// Get implements [flag.Getter].
+func (f *FileFlag) Get() any {
+	return f.Path
+}
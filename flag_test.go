@@ -0,0 +1,124 @@
+package pathlib_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDirFlag() {
+	flags := flag.NewFlagSet("example", flag.ContinueOnError)
+	out := pathlib.DirFlag{Path: "out"}
+	out.Base = "/srv"
+	flags.Var(&out, "out", "where to write results")
+	enforce(flags.Parse([]string{"-out", "www/../site"}))
+	fmt.Println(out.Path)
+	// Output:
+	// /srv/site
+}
+
+func newFlagSet() *flag.FlagSet {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+func TestDirFlag(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("file").AsFile(), "")
+		opts := pathlib.FlagOptions{DecodeOptions: pathlib.DecodeOptions{Base: temp, Stat: true}}
+
+		dir := pathlib.DirFlag{FlagOptions: opts}
+		flags := newFlagSet()
+		flags.Var(&dir, "dir", "")
+		enforce(flags.Parse([]string{"-dir", "."}))
+		if !dir.Path.Eq(temp) {
+			t.Errorf("expected %q, got %q", temp, dir.Path)
+		}
+		if flags.Lookup("dir").Value.(flag.Getter).Get() != dir.Path {
+			t.Error("expected Get to return the path")
+		}
+
+		err := dir.Set("file")
+		var wrong pathlib.WrongTypeOnDisk[pathlib.Dir]
+		if !errors.As(err, &wrong) || !strings.HasPrefix(err.Error(), "expected a directory: ") {
+			t.Errorf("expected WrongTypeOnDisk, got %v", err)
+		}
+		if !dir.Path.Eq(temp) {
+			t.Errorf("expected a failed Set to keep %q, got %q", temp, dir.Path)
+		}
+		if err := dir.Set("missing"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected ErrNotExist, got %v", err)
+		}
+
+		dir.MakeAll = true
+		enforce(dir.Set("a/b"))
+		if _, err := temp.Join("a", "b").AsDir().Stat(); err != nil {
+			t.Error(err)
+		}
+		if err := dir.Set("file"); !errors.As(err, &wrong) {
+			t.Errorf("expected WrongTypeOnDisk, got %v", err)
+		}
+	})
+}
+
+func TestFileFlag(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("file").AsFile(), "")
+		file := pathlib.FileFlag{FlagOptions: pathlib.FlagOptions{
+			DecodeOptions: pathlib.DecodeOptions{Base: temp, Stat: true},
+		}}
+		enforce(file.Set("file"))
+		if !file.Path.Eq(temp.Join("file").AsFile()) {
+			t.Errorf("unexpected path %q", file.Path)
+		}
+		err := file.Set(".")
+		var wrong pathlib.WrongTypeOnDisk[pathlib.File]
+		if !errors.As(err, &wrong) || !strings.HasPrefix(err.Error(), "expected a file: ") {
+			t.Errorf("expected WrongTypeOnDisk, got %v", err)
+		}
+
+		// MakeAll creates the parents, but not the file itself
+		file.MakeAll, file.Stat = true, false
+		enforce(file.Set("x/y/new.txt"))
+		if !temp.Join("x", "y").AsDir().Exists() || file.Path.Exists() {
+			t.Error("expected only the parent directories to be made")
+		}
+	})
+}
+
+func TestPathFlag(t *testing.T) {
+	wd := expect(pathlib.Cwd())
+	home := expect(pathlib.UserHomeDir())
+
+	p := pathlib.PathFlag{Path: "default"}
+	if p.String() != "default" {
+		t.Errorf("unexpected String() %q", p.String())
+	}
+	enforce(p.Set("rel"))
+	if p.Path != "rel" {
+		t.Errorf("expected no processing by default, got %q", p.Path)
+	}
+	p.Abs = true
+	enforce(p.Set("rel"))
+	if p.Path != wd.Join("rel") {
+		t.Errorf("expected %q, got %q", wd.Join("rel"), p.Path)
+	}
+	p.ExpandUser = true
+	enforce(p.Set("~/rel"))
+	if p.Path != home.Join("rel") {
+		t.Errorf("expected %q, got %q", home.Join("rel"), p.Path)
+	}
+
+	p.Stat = true
+	err := p.Set(string(pathlib.Dir(t.TempDir()).Join("missing")))
+	if !errors.Is(err, fs.ErrNotExist) || !strings.HasPrefix(err.Error(), "expected a path: ") {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+}
@@ -0,0 +1,485 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+)
+
+// The syntax of a family of paths: its separators, volume names and case sensitivity.
+// The windows flavor is a port of [path/filepath]'s Windows rules, so that Windows
+// paths can be manipulated on any OS.
+type flavor struct {
+	separator byte
+	windows   bool
+}
+
+var (
+	posixFlavor   = &flavor{separator: '/'}
+	windowsFlavor = &flavor{separator: '\\', windows: true}
+)
+
+func (fl *flavor) isSeparator(c byte) bool {
+	return c == fl.separator || (fl.windows && c == '/')
+}
+
+// like [strings.ContainsFunc] for separators.
+func (fl *flavor) hasSeparator(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < 0x80 && fl.isSeparator(byte(r)) }) >= 0
+}
+
+// See [path/filepath.FromSlash].
+func (fl *flavor) fromSlash(s string) string {
+	if fl.windows {
+		return strings.ReplaceAll(s, "/", `\`)
+	}
+	return s
+}
+
+// case-insensitively on Windows.
+func (fl *flavor) sameWord(a, b string) bool {
+	if fl.windows {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// See [path/filepath.VolumeName].
+func (fl *flavor) volumeName(s string) string {
+	return fl.fromSlash(s[:fl.volumeNameLen(s)])
+}
+
+func (fl *flavor) volumeNameLen(s string) int {
+	if !fl.windows {
+		return 0
+	}
+	switch {
+	case len(s) >= 2 && s[1] == ':':
+		return 2 // a drive letter
+	case len(s) == 0 || !fl.isSeparator(s[0]):
+		return 0
+	case fl.hasPrefixFold(s, `\\.`) || fl.hasPrefixFold(s, `\\?`) || fl.hasPrefixFold(s, `\??`):
+		// a device path: the next component is part of the volume
+		switch {
+		case len(s) == 3:
+			return 3
+		case fl.hasPrefixFold(s[4:], `UNC`):
+			return fl.validVolumeNameLen(s, fl.uncLen(s, len(`\\.\UNC\`)))
+		}
+		_, rest, ok := fl.cut(s[4:])
+		if !ok {
+			return fl.validVolumeNameLen(s, len(s))
+		}
+		return fl.validVolumeNameLen(s, len(s)-len(rest)-1)
+	case len(s) >= 2 && fl.isSeparator(s[1]):
+		return fl.validVolumeNameLen(s, fl.uncLen(s, 2)) // \\host\share
+	}
+	return 0
+}
+
+// returns n unless s[:n] contains a ".." component.
+func (fl *flavor) validVolumeNameLen(s string, n int) int {
+	for p := s[:n]; p != ""; {
+		var part string
+		part, p, _ = fl.cut(p)
+		if part == ".." {
+			return 0
+		}
+	}
+	return n
+}
+
+// tests whether s begins with the component prefix, ignoring case and which
+// separators are used.
+func (fl *flavor) hasPrefixFold(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if fl.isSeparator(prefix[i]) {
+			if !fl.isSeparator(s[i]) {
+				return false
+			}
+		} else if toUpper(prefix[i]) != toUpper(s[i]) {
+			return false
+		}
+	}
+	return len(s) == len(prefix) || fl.isSeparator(s[len(prefix)])
+}
+
+// returns the length of the host and share of a UNC path starting at offset.
+func (fl *flavor) uncLen(s string, offset int) int {
+	count := 0
+	for i := offset; i < len(s); i++ {
+		if fl.isSeparator(s[i]) {
+			count++
+			if count == 2 {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+// slices s around the first separator.
+func (fl *flavor) cut(s string) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		if fl.isSeparator(s[i]) {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func toUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
+
+// See [path/filepath.IsAbs].
+func (fl *flavor) isAbs(s string) bool {
+	if !fl.windows {
+		return strings.HasPrefix(s, "/")
+	}
+	l := fl.volumeNameLen(s)
+	if l == 0 {
+		return false
+	}
+	if fl.isSeparator(s[0]) && fl.isSeparator(s[1]) {
+		return true // UNC and device paths
+	}
+	s = s[l:]
+	return s != "" && fl.isSeparator(s[0])
+}
+
+// See [path/filepath.Clean].
+func (fl *flavor) clean(s string) string {
+	original := s
+	volLen := fl.volumeNameLen(s)
+	s = s[volLen:]
+	if s == "" {
+		if volLen > 1 && fl.isSeparator(original[0]) && fl.isSeparator(original[1]) {
+			return fl.fromSlash(original) // UNC
+		}
+		return original + "."
+	}
+	rooted := fl.isSeparator(s[0])
+
+	// out holds the result; diverged is set once it stops being a copy of s.
+	// dotdot is where ".." must stop backtracking.
+	out := make([]byte, 0, len(s))
+	diverged := false
+	add := func(c byte) {
+		if !diverged && (len(out) >= len(s) || s[len(out)] != c) {
+			diverged = true
+		}
+		out = append(out, c)
+	}
+	n := len(s)
+	r, dotdot := 0, 0
+	if rooted {
+		add(fl.separator)
+		r, dotdot = 1, 1
+	}
+	for r < n {
+		switch {
+		case fl.isSeparator(s[r]):
+			r++
+		case s[r] == '.' && (r+1 == n || fl.isSeparator(s[r+1])):
+			r++
+		case s[r] == '.' && s[r+1] == '.' && (r+2 == n || fl.isSeparator(s[r+2])):
+			r += 2
+			switch {
+			case len(out) > dotdot:
+				w := len(out) - 1
+				for w > dotdot && !fl.isSeparator(out[w]) {
+					w--
+				}
+				out = out[:w]
+			case !rooted:
+				if len(out) > 0 {
+					add(fl.separator)
+				}
+				add('.')
+				add('.')
+				dotdot = len(out)
+			}
+		default:
+			if rooted && len(out) != 1 || !rooted && len(out) != 0 {
+				add(fl.separator)
+			}
+			for ; r < n && !fl.isSeparator(s[r]); r++ {
+				add(s[r])
+			}
+		}
+	}
+	if len(out) == 0 {
+		add('.')
+	}
+	if fl.windows && volLen == 0 && diverged {
+		out = windowsPostClean(out)
+	}
+	return fl.fromSlash(original[:volLen] + string(out))
+}
+
+// avoids turning a relative Windows path into a drive-relative or device path.
+func windowsPostClean(out []byte) []byte {
+	for _, c := range out {
+		if windowsFlavor.isSeparator(c) {
+			break
+		}
+		if c == ':' {
+			return append([]byte(`.\`), out...) // a/../c: isn't c:
+		}
+	}
+	if len(out) >= 3 && windowsFlavor.isSeparator(out[0]) && out[1] == '?' && out[2] == '?' {
+		return append([]byte(`\.`), out...) // \a\..\??\c:\x isn't \??\c:\x
+	}
+	return out
+}
+
+// See [path/filepath.Join].
+func (fl *flavor) join(elems ...string) string {
+	var b strings.Builder
+	var last byte
+	for _, e := range elems {
+		if !fl.windows {
+			if e == "" {
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteByte('/')
+			}
+			b.WriteString(e)
+			continue
+		}
+		switch {
+		case b.Len() == 0:
+		case fl.isSeparator(last):
+			// don't create a UNC path from non-UNC elements
+			for len(e) > 0 && fl.isSeparator(e[0]) {
+				e = e[1:]
+			}
+			if b.Len() == 1 && strings.HasPrefix(e, "??") && (len(e) == 2 || fl.isSeparator(e[2])) {
+				b.WriteString(`.\`) // \.\?? rather than the device path \??\
+			}
+		case last == ':':
+			// keep C:f relative to the drive's working directory
+		default:
+			b.WriteByte('\\')
+			last = '\\'
+		}
+		if len(e) > 0 {
+			b.WriteString(e)
+			last = e[len(e)-1]
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return fl.clean(b.String())
+}
+
+// See [path/filepath.Dir].
+func (fl *flavor) dir(s string) string {
+	vol := fl.volumeName(s)
+	i := len(s) - 1
+	for i >= len(vol) && !fl.isSeparator(s[i]) {
+		i--
+	}
+	d := fl.clean(s[len(vol) : i+1])
+	if d == "." && len(vol) > 2 {
+		return vol // UNC
+	}
+	return vol + d
+}
+
+// See [path/filepath.Base].
+func (fl *flavor) base(s string) string {
+	if s == "" {
+		return "."
+	}
+	for len(s) > 0 && fl.isSeparator(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	s = s[fl.volumeNameLen(s):]
+	i := len(s) - 1
+	for i >= 0 && !fl.isSeparator(s[i]) {
+		i--
+	}
+	if i >= 0 {
+		s = s[i+1:]
+	}
+	if s == "" {
+		return string(fl.separator)
+	}
+	return s
+}
+
+// See [path/filepath.Ext].
+func (fl *flavor) ext(s string) string {
+	for i := len(s) - 1; i >= 0 && !fl.isSeparator(s[i]); i-- {
+		if s[i] == '.' {
+			return s[i:]
+		}
+	}
+	return ""
+}
+
+// See [PathStr.Parts].
+func (fl *flavor) parts(s string) (parts []string) {
+	if vol := fl.volumeName(s); vol != "" {
+		parts = append(parts, vol)
+		s = s[len(vol):]
+	}
+	if s == "" {
+		return
+	}
+	if fl.isSeparator(s[0]) {
+		parts = append(parts, s[:1])
+		s = s[1:]
+	}
+	for s != "" {
+		var part string
+		part, s, _ = fl.cut(s)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return
+}
+
+// See [path/filepath.Rel].
+func (fl *flavor) rel(basePath, targPath string) (string, error) {
+	baseVol, targVol := fl.volumeName(basePath), fl.volumeName(targPath)
+	base, targ := fl.clean(basePath), fl.clean(targPath)
+	if fl.sameWord(targ, base) {
+		return ".", nil
+	}
+	base, targ = base[len(baseVol):], targ[len(targVol):]
+	if base == "." {
+		base = ""
+	} else if base == "" && fl.volumeNameLen(baseVol) > 2 {
+		base = string(fl.separator) // \\host\share is absolute
+	}
+	sep := fl.separator
+	baseSlashed := len(base) > 0 && base[0] == sep
+	targSlashed := len(targ) > 0 && targ[0] == sep
+	if baseSlashed != targSlashed || !fl.sameWord(baseVol, targVol) {
+		return "", errors.New("Rel: can't make " + targPath + " relative to " + basePath)
+	}
+	bl, tl := len(base), len(targ)
+	var b0, bi, t0, ti int
+	for {
+		for bi < bl && base[bi] != sep {
+			bi++
+		}
+		for ti < tl && targ[ti] != sep {
+			ti++
+		}
+		if !fl.sameWord(targ[t0:ti], base[b0:bi]) {
+			break
+		}
+		if bi < bl {
+			bi++
+		}
+		if ti < tl {
+			ti++
+		}
+		b0, t0 = bi, ti
+	}
+	if base[b0:bi] == ".." {
+		return "", errors.New("Rel: can't make " + targPath + " relative to " + basePath)
+	}
+	if b0 != bl {
+		// go up out of the rest of base before going down into targ
+		up := ".." + strings.Repeat(string(sep)+"..", strings.Count(base[b0:bl], string(sep)))
+		if t0 != tl {
+			up += string(sep) + targ[t0:]
+		}
+		return fl.clean(up), nil
+	}
+	return targ[t0:], nil
+}
+
+// See [path/filepath.IsLocal]. Windows names like "CON.txt" are conservatively
+// treated as reserved, as they were before Windows 11.
+func (fl *flavor) isLocal(s string) bool {
+	if s == "" || fl.isAbs(s) {
+		return false
+	}
+	if fl.windows && (fl.isSeparator(s[0]) || strings.IndexByte(s, ':') >= 0) {
+		return false // rooted in the current drive, or a drive-relative path
+	}
+	for p := s; p != ""; {
+		var part string
+		part, p, _ = fl.cut(p)
+		if fl.windows && isReservedWindowsName(part) {
+			return false
+		}
+	}
+	s = fl.clean(s)
+	return s != ".." && !strings.HasPrefix(s, ".."+string(fl.separator))
+}
+
+// See [path/filepath.Localize].
+func (fl *flavor) localize(s string) (string, error) {
+	if !fs.ValidPath(s) {
+		return "", errInvalidPath
+	}
+	if !fl.windows {
+		if strings.IndexByte(s, 0) >= 0 {
+			return "", errInvalidPath
+		}
+		return s, nil
+	}
+	if strings.ContainsAny(s, ":\\\x00") {
+		return "", errInvalidPath
+	}
+	for _, part := range strings.Split(s, "/") {
+		if isReservedWindowsName(part) {
+			return "", errInvalidPath
+		}
+	}
+	return fl.fromSlash(s), nil
+}
+
+var errInvalidPath = errors.New("invalid path")
+
+// reports whether name is a Windows device name like "NUL", ignoring any extension and
+// trailing spaces.
+func isReservedWindowsName(name string) bool {
+	if i := strings.IndexAny(name, ".:"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ToUpper(strings.TrimRight(name, " "))
+	switch name {
+	case "CON", "PRN", "AUX", "NUL", "CONIN$", "CONOUT$":
+		return true
+	}
+	if len(name) >= 4 && (name[:3] == "COM" || name[:3] == "LPT") {
+		switch name[3:] {
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9", "\u00b9", "\u00b2", "\u00b3":
+			return true
+		}
+	}
+	return false
+}
+
+// Converting ------------------------------------------------------------------
+
+// replaces fl's separators with to's.
+func (fl *flavor) convert(s string, to *flavor) string {
+	if fl == to {
+		return s
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if fl.isSeparator(c) {
+			b[i] = to.separator
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package pathlib
+
+// the flavor of [PathStr].
+var hostFlavor = posixFlavor
@@ -0,0 +1,276 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleWindowsPath() {
+	p := pathlib.WindowsPath(`C:\Users\Public\..\alice\Documents\report.docx`)
+	fmt.Println(p.Clean())
+	fmt.Println(p.IsAbsolute(), p.Stem(), p.Parent())
+	fmt.Println(pathlib.WindowsPath(p.Join("..", "notes.txt")).AsPosix())
+	fmt.Println(pathlib.WindowsPath(`\\server\share\dir`).Eq(`\\SERVER\Share\DIR\`))
+	// Output:
+	// C:\Users\alice\Documents\report.docx
+	// true report C:\Users\alice\Documents
+	// C:/Users/alice/Documents/notes.txt
+	// true
+}
+
+func ExamplePosixPath() {
+	p := pathlib.PosixPath("/srv/app/../www/index.html")
+	fmt.Println(p.Clean(), p.IsAbsolute())
+	fmt.Println(expect(p.Clean().RelativeTo("/srv")).AsWindows())
+	// Output:
+	// /srv/www/index.html true
+	// www\index.html
+}
+
+// compares the host's flavor against path/filepath.
+func TestFlavor_host(t *testing.T) {
+	inputs := []string{
+		"", ".", "..", "/", "//", "a", "a/", "a/b", "/a/b/", "a//b", "a/./b", "a/../b",
+		"../a", "/../a", "a/b/../..", "a/b/../../..", ".hidden", "a/b.tar.gz", "a.",
+	}
+	if runtime.GOOS == "windows" {
+		inputs = append(inputs, `C:`, `C:\`, `C:a\..\b`, `\\host\share`, `\\host\share\a\..`, `\\?\C:\a`, `a\..\c:`)
+	}
+	type pure interface {
+		pathlib.PurePath
+		pathlib.Stemmer
+		String() string
+	}
+	convert := func(s string) pure {
+		if runtime.GOOS == "windows" {
+			return pathlib.WindowsPath(s)
+		}
+		return pathlib.PosixPath(s)
+	}
+	for _, s := range inputs {
+		p := convert(s)
+		check := func(method string, actual, expected any) {
+			t.Helper()
+			if fmt.Sprint(actual) != fmt.Sprint(expected) {
+				t.Errorf("%T(%q).%s: expected %q, got %q", p, s, method, expected, actual)
+			}
+		}
+		check("Join", p.Join("x", "y"), filepath.Join(s, "x", "y"))
+		check("Parent", p.Parent(), filepath.Dir(filepath.Clean(s)))
+		check("BaseName", p.BaseName(), filepath.Base(s))
+		check("Ext", p.Ext(), filepath.Ext(s))
+		check("IsAbsolute", p.IsAbsolute(), filepath.IsAbs(s))
+		check("IsLocal", p.IsLocal(), filepath.IsLocal(s))
+		check("Parts", p.Parts(), pathlib.PathStr(s).Parts())
+		check("Stem", p.Stem(), pathlib.PathStr(s).Stem())
+		for _, base := range inputs {
+			_, relErr := filepath.Rel(base, s)
+			var actualErr error
+			if runtime.GOOS == "windows" {
+				_, actualErr = pathlib.WindowsPath(s).Rel(pathlib.Dir(base))
+			} else {
+				_, actualErr = pathlib.PosixPath(s).Rel(pathlib.Dir(base))
+			}
+			check("Rel("+base+") error", actualErr == nil, relErr == nil)
+		}
+	}
+}
+
+func TestPosixPath(t *testing.T) {
+	cases := []struct{ path, clean string }{
+		{"", "."},
+		{"a//b/./c/..", "a/b"},
+		{`a\b`, `a\b`},
+		{"/../a", "/a"},
+		{"../../a", "../../a"},
+		{"C:/a", "C:/a"},
+	}
+	for _, c := range cases {
+		if actual := pathlib.PosixPath(c.path).Clean(); string(actual) != c.clean {
+			t.Errorf("%q.Clean(): expected %q, got %q", c.path, c.clean, actual)
+		}
+	}
+	p := pathlib.PosixPath(`dir/back\slash.txt`)
+	if parts := p.Parts(); !slices.Equal(parts, []string{"dir", `back\slash.txt`}) {
+		t.Errorf("expected backslashes to be part of names, got %q", parts)
+	}
+	if p.Eq("DIR/back\\slash.txt") {
+		t.Error("expected POSIX paths to be case-sensitive")
+	}
+	if _, err := pathlib.PosixPath("a/b").Localize(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := pathlib.PosixPath("/a").Localize(); err == nil {
+		t.Error("expected an error localizing an absolute path")
+	}
+}
+
+func TestWindowsPath_Clean(t *testing.T) {
+	// from path/filepath's tests
+	cases := []struct{ path, clean string }{
+		{`c:`, `c:.`},
+		{`c:\`, `c:\`},
+		{`c:abc\..\..\.\.\..\def`, `c:..\..\def`},
+		{`c:\abc\def\..\..`, `c:\`},
+		{`c:\..\abc`, `c:\abc`},
+		{`c:\b:\..\..\..\d`, `c:\d`},
+		{`/`, `\`},
+		{`\\i\..\c$`, `\c$`},
+		{`//../../a`, `\a`},
+		{`\\host\share\foo\..\bar`, `\\host\share\bar`},
+		{`//host/share/foo/../baz`, `\\host\share\baz`},
+		{`\\host\share\foo\..\..\..\..\bar`, `\\host\share\bar`},
+		{`\\?\UNC\host\share\foo\..\..\..\..\bar`, `\\?\UNC\host\share\bar`},
+		{`\\.\C:\a\..\..\..\..\bar`, `\\.\C:\bar`},
+		{`\\a\b`, `\\a\b`},
+		{`.\c:foo`, `.\c:foo`},
+		{`//abc//`, `\\abc\\`},
+		{`\\?\C:\`, `\\?\C:\`},
+		{`a/../c:`, `.\c:`},
+		{`a/../../c:`, `..\c:`},
+		{`foo:bar`, `foo:bar`},
+		{`/a/../??/a`, `\.\??\a`},
+	}
+	for _, c := range cases {
+		if actual := pathlib.WindowsPath(c.path).Clean(); string(actual) != c.clean {
+			t.Errorf("%q.Clean(): expected %q, got %q", c.path, c.clean, actual)
+		}
+	}
+}
+
+func TestWindowsPath_Join(t *testing.T) {
+	cases := []struct {
+		elems    []string
+		expected string
+	}{
+		{[]string{`C:\Windows\`, `System32`}, `C:\Windows\System32`},
+		{[]string{`C:`, `a`, `b`}, `C:a\b`},
+		{[]string{`C:`, ``}, `C:.`},
+		{[]string{`C:`, `\a`}, `C:\a`},
+		{[]string{`//host/share`, `foo/bar`}, `\\host\share\foo\bar`},
+		{[]string{`\`, `\\a\b`, `c`}, `\a\b\c`},
+		{[]string{`\\`, `a`, `b`}, `\\a\b`},
+		{[]string{`a:\b\c`, `x\..\y:\..\..\z`}, `a:\b\z`},
+		{[]string{`\`, `??\a`}, `\.\??\a`},
+	}
+	for _, c := range cases {
+		if actual := pathlib.WindowsPath(c.elems[0]).Join(c.elems[1:]...); string(actual) != c.expected {
+			t.Errorf("Join(%q): expected %q, got %q", c.elems, c.expected, actual)
+		}
+	}
+}
+
+func TestWindowsPath_pure(t *testing.T) {
+	type pure struct {
+		parent, base string
+		abs, local   bool
+		parts        []string
+	}
+	cases := map[pathlib.WindowsPath]pure{
+		`c:\a\b`:            {`c:\a`, `b`, true, false, []string{`c:`, `\`, `a`, `b`}},
+		`c:a\b`:             {`c:a`, `b`, false, false, []string{`c:`, `a`, `b`}},
+		`\\host\share\a`:    {`\\host\share\`, `a`, true, false, []string{`\\host\share`, `\`, `a`}},
+		`\\host\share`:      {`\\host\share`, `\`, true, false, []string{`\\host\share`}},
+		`\Windows`:          {`\`, `Windows`, false, false, []string{`\`, `Windows`}},
+		`a/b\c`:             {`a\b`, `c`, false, true, []string{`a`, `b`, `c`}},
+		`docs\nul.txt`:      {`docs`, `nul.txt`, false, false, []string{`docs`, `nul.txt`}},
+		`\\?\C:\dir\f.txt`:  {`\\?\C:\dir`, `f.txt`, true, false, []string{`\\?\C:`, `\`, `dir`, `f.txt`}},
+		`..\escape`:         {`..`, `escape`, false, false, []string{`..`, `escape`}},
+		`com0\fine`:         {`com0`, `fine`, false, true, []string{`com0`, `fine`}},
+		`C:\Program Files\`: {`C:\`, `Program Files`, true, false, []string{`C:`, `\`, `Program Files`}},
+	}
+	for p, expected := range cases {
+		actual := pure{string(p.Parent()), p.BaseName(), p.IsAbsolute(), p.IsLocal(), p.Parts()}
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("%q: expected %v, got %v", p, expected, actual)
+		}
+	}
+}
+
+func TestWindowsPath_Rel(t *testing.T) {
+	cases := []struct{ base, target, expected string }{
+		{`C:a\b\c`, `C:a/b/d`, `..\d`},
+		{`C:\`, `D:\`, "err"},
+		{`C:\Projects`, `c:\projects\src`, `src`},
+		{`C:\Projects\a\..`, `c:\projects`, `.`},
+		{`\\host\share`, `\\host\share\file.txt`, `file.txt`},
+	}
+	for _, c := range cases {
+		actual, err := pathlib.WindowsPath(c.target).Rel(pathlib.Dir(c.base))
+		if c.expected == "err" {
+			if err == nil {
+				t.Errorf("Rel(%q, %q): expected an error, got %q", c.base, c.target, actual)
+			}
+			continue
+		}
+		if err != nil || string(actual) != c.expected {
+			t.Errorf("Rel(%q, %q): expected %q, got %q, %v", c.base, c.target, c.expected, actual, err)
+		}
+	}
+}
+
+func TestWindowsPath_names(t *testing.T) {
+	p := pathlib.WindowsPath(`C:\Data\Archive.TAR.GZ`)
+	if suffixes := p.Suffixes(); !slices.Equal(suffixes, []string{".TAR", ".GZ"}) {
+		t.Errorf("unexpected suffixes %q", suffixes)
+	}
+	if actual := expect(p.WithSuffix(".zip")); actual != `C:\Data\Archive.TAR.zip` {
+		t.Errorf("unexpected %q", actual)
+	}
+	if _, err := p.WithName(`sub\name`); !errors.Is(err, pathlib.ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+	if _, err := p.WithName(`sub/name`); !errors.Is(err, pathlib.ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+	if _, err := pathlib.WindowsPath(`C:\`).WithName("x"); !errors.Is(err, pathlib.ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+	if actual := expect(p.RelativeTo(`c:\data`)); actual != `Archive.TAR.GZ` {
+		t.Errorf("expected a case-insensitive match, got %q", actual)
+	}
+	if p.IsRelativeTo(`D:\Data`) || p.IsRelativeTo(`Data`) {
+		t.Error("expected other volumes and relative paths not to match")
+	}
+	if actual := expect(pathlib.WindowsPath("a/b.txt").Localize()); actual != `a\b.txt` {
+		t.Errorf("unexpected %q", actual)
+	}
+	for _, invalid := range []string{"a/nul", "c:/x", `a\b`, "/a"} {
+		if _, err := pathlib.WindowsPath(invalid).Localize(); err == nil {
+			t.Errorf("expected an error localizing %q", invalid)
+		}
+	}
+}
+
+func TestFlavor_conversions(t *testing.T) {
+	win := pathlib.WindowsPath(`C:\Users\me/file.txt`)
+	if actual := win.AsPosix(); actual != "C:/Users/me/file.txt" {
+		t.Errorf("unexpected %q", actual)
+	}
+	posix := pathlib.PosixPath("/etc/app/config")
+	if actual := posix.AsWindows(); actual != `\etc\app\config` {
+		t.Errorf("unexpected %q", actual)
+	}
+	if actual := posix.AsWindows().AsPosix(); actual != posix {
+		t.Errorf("expected a round trip, got %q", actual)
+	}
+	native := pathlib.PathStr(filepath.Join("a", "b"))
+	if actual := native.AsPosix(); actual != "a/b" {
+		t.Errorf("unexpected %q", actual)
+	}
+	if actual := native.AsWindows(); actual != `a\b` {
+		t.Errorf("unexpected %q", actual)
+	}
+	if actual := native.AsPosix().AsPathStr(); actual != native {
+		t.Errorf("expected a round trip, got %q", actual)
+	}
+	if actual := native.AsWindows().AsPathStr(); actual != native {
+		t.Errorf("expected a round trip, got %q", actual)
+	}
+}
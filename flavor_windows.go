@@ -0,0 +1,4 @@
+package pathlib
+
+// the flavor of [PathStr].
+var hostFlavor = windowsFlavor
@@ -0,0 +1,10 @@
+//go:build unix && !linux
+
+package pathlib
+
+import "io/fs"
+
+// See [DirHandle.Stat].
+func fstatat(dirfd int, name string, follow bool) (fs.FileInfo, error) {
+	return fstatatInfo(dirfd, name, follow)
+}
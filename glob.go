@@ -0,0 +1,360 @@
+package pathlib
+
+import (
+	"iter"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// A compiled glob pattern that can be matched against any [PurePath] without I/O.
+//
+// Within a path segment, the syntax is that of [path/filepath.Match]. In addition:
+//   - a segment that is exactly "**" matches zero or more whole segments
+//   - "{a,b,c}" expands to each of its comma-separated alternatives, and may be nested
+//   - a leading "!" negates the pattern; see [Patterns]
+//
+// Wildcards match names that start with a ".".
+type Pattern struct {
+	raw          string
+	negated      bool
+	alternatives [][]string // the segments of each brace expansion
+}
+
+// Parses a glob pattern, returning [path/filepath.ErrBadPattern] if it is malformed.
+func CompilePattern(pattern string) (Pattern, error) {
+	p := Pattern{raw: pattern}
+	if strings.HasPrefix(pattern, "!") {
+		p.negated = true
+		pattern = pattern[1:]
+	}
+	expanded, err := expandBraces(pattern)
+	if err != nil {
+		return Pattern{}, err
+	}
+	for _, alt := range expanded {
+		segments := withoutDots(PathStr(alt).Parts())
+		for _, segment := range segments {
+			// check that each segment is well-formed
+			if _, err := filepath.Match(segment, ""); err != nil {
+				return Pattern{}, err
+			}
+		}
+		p.alternatives = append(p.alternatives, segments)
+	}
+	return p, nil
+}
+
+// Like [CompilePattern], but panics if the pattern is malformed.
+func MustCompilePattern(pattern string) Pattern {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Returns the pattern as written.
+func (p Pattern) String() string {
+	return p.raw
+}
+
+// Returns true if the pattern started with a "!".
+func (p Pattern) Negated() bool {
+	return p.negated
+}
+
+// Returns true if the path matches the pattern, ignoring any negation.
+func (p Pattern) Match(path PurePath) bool {
+	return p.match(withoutDots(path.Parts()), false)
+}
+
+// In prefix mode, returns true if some descendant of the path could match the pattern.
+func (p Pattern) match(parts []string, prefix bool) bool {
+	for _, segments := range p.alternatives {
+		if matchSegments(segments, parts, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// An ordered list of patterns where, like in a .gitignore file, the last matching
+// pattern wins: a path matches if the last pattern that matches it isn't negated. A
+// list of only negated patterns matches every path that none of them match.
+type Patterns []Pattern
+
+// Compiles each of the given patterns. See [CompilePattern].
+func CompilePatterns(patterns ...string) (Patterns, error) {
+	result := make(Patterns, len(patterns))
+	for i, pattern := range patterns {
+		var err error
+		if result[i], err = CompilePattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Returns true if the last pattern that matches the path isn't negated.
+func (ps Patterns) Match(path PurePath) bool {
+	return ps.match(withoutDots(path.Parts()))
+}
+
+func (ps Patterns) match(parts []string) (matched bool) {
+	matched = ps.onlyNegated()
+	for _, p := range ps {
+		if p.match(parts, false) {
+			matched = !p.negated
+		}
+	}
+	return
+}
+
+// returns true if a descendant of the path could be matched by a non-negated pattern.
+func (ps Patterns) matchPrefix(parts []string) bool {
+	if ps.onlyNegated() {
+		return true
+	}
+	for _, p := range ps {
+		if !p.negated && p.match(parts, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns true if a non-negated pattern would look inside the path through a segment
+// other than "**"; see [Dir.GlobSeq].
+func (ps Patterns) follow(parts []string) bool {
+	for _, p := range ps {
+		if p.negated {
+			continue
+		}
+		for _, segments := range p.alternatives {
+			if followSegments(segments, parts) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// returns true if the list is non-empty and every pattern is negated.
+func (ps Patterns) onlyNegated() bool {
+	for _, p := range ps {
+		if !p.negated {
+			return false
+		}
+	}
+	return len(ps) > 0
+}
+
+// Lazily yield an [Entry] for every path beneath d whose path relative to d matches
+// the patterns, in the same order as [Dir.WalkSeq]. Directories that can't contain a
+// match are not read.
+//
+// Like [path/filepath.Glob], symlinks to directories are followed where a segment
+// other than "**" matches them and more segments follow. Symlinks matched by "**"
+// are not followed. Links that lead back to a directory being searched are yielded
+// with a [syscall.ELOOP] error.
+func (d Dir) GlobSeq(patterns ...Pattern) iter.Seq2[Entry, error] {
+	ps := Patterns(patterns)
+	relParts := func(p PathStr) []string {
+		rel, err := filepath.Rel(string(d), string(p))
+		if err != nil {
+			return nil
+		}
+		return withoutDots(PathStr(rel).Parts())
+	}
+	opts := WalkOptions{
+		Prune: func(entry Entry) bool {
+			return !ps.matchPrefix(relParts(entry.Path()))
+		},
+		Follow: func(entry Entry) bool {
+			return ps.follow(relParts(entry.Path()))
+		},
+	}
+	return func(yield func(Entry, error) bool) {
+		for entry, err := range d.WalkSeq(opts) {
+			if err != nil {
+				if !yield(entry, err) {
+					return
+				}
+				continue
+			}
+			parts := relParts(entry.Path())
+			if len(parts) > 0 && ps.match(parts) && !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Joins the pattern onto d like [path/filepath.Glob] would, then splits it into the
+// directory named by its leading wildcard-free segments and the rest of the pattern.
+func globBase(d Dir, pattern string) (base Dir, rest string) {
+	joined := hostFlavor.join(string(d), pattern)
+	start := len(hostFlavor.volumeName(joined))
+	for start < len(joined) && hostFlavor.isSeparator(joined[start]) {
+		start++ // keep the root in the base
+	}
+	end := start
+	for i := start; i < len(joined); {
+		j := i
+		for j < len(joined) && !hostFlavor.isSeparator(joined[j]) {
+			j++
+		}
+		if hasGlobMeta(joined[i:j]) {
+			break
+		}
+		end, i = j, j+1
+	}
+	base, rest = Dir(joined[:end]), joined[end:]
+	for len(rest) > 0 && hostFlavor.isSeparator(rest[0]) {
+		rest = rest[1:]
+	}
+	if base == "" {
+		base = "."
+	}
+	return
+}
+
+// returns true if the segment contains wildcards, braces or escapes.
+func hasGlobMeta(segment string) bool {
+	meta := `*?[{\`
+	if runtime.GOOS == "windows" {
+		meta = `*?[{`
+	}
+	return strings.ContainsAny(segment, meta)
+}
+
+// drops "." segments, which don't affect what a path refers to.
+func withoutDots(parts []string) []string {
+	result := parts[:0:0]
+	for _, part := range parts {
+		if part != "." {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// matches path segments against pattern segments. In prefix mode, running out of
+// path segments before running out of pattern segments counts as a match.
+func matchSegments(pattern, parts []string, prefix bool) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range len(parts) + 1 {
+				if matchSegments(pattern, parts[i:], prefix) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return prefix
+		}
+		if ok, _ := filepath.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// returns true if the last path segment can be matched by a pattern segment other
+// than "**" that isn't the last one.
+func followSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 && len(parts) > 0 {
+		if pattern[0] == "**" {
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			for i := range parts {
+				if followSegments(pattern, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+		if len(parts) == 0 {
+			return len(pattern) > 0
+		}
+	}
+	return false
+}
+
+// Expands "{a,b}" groups into every combination of their alternatives.
+func expandBraces(pattern string) ([]string, error) {
+	open := -1
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if runtime.GOOS != "windows" {
+				i++ // skip the escaped character
+			}
+		case '{':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				return nil, filepath.ErrBadPattern
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			prefix, suffix := pattern[:open], pattern[i+1:]
+			var result []string
+			for _, alt := range splitAlternatives(pattern[open+1 : i]) {
+				expanded, err := expandBraces(prefix + alt + suffix)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, expanded...)
+			}
+			return result, nil
+		}
+	}
+	if depth != 0 {
+		return nil, filepath.ErrBadPattern
+	}
+	return []string{pattern}, nil
+}
+
+// splits the body of a brace group on its top-level commas.
+func splitAlternatives(body string) (alternatives []string) {
+	depth, last := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			if runtime.GOOS != "windows" {
+				i++
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				alternatives = append(alternatives, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(alternatives, body[last:])
+}
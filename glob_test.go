@@ -0,0 +1,226 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExamplePattern_Match() {
+	pattern := pathlib.MustCompilePattern("{cmd,internal}/**/*_test.go")
+	for _, p := range []pathlib.PathStr{
+		"cmd/main_test.go",
+		"internal/a/b/c_test.go",
+		"pkg/lib_test.go",
+		"cmd/main.go",
+	} {
+		fmt.Println(p, pattern.Match(p))
+	}
+	// Output:
+	// cmd/main_test.go true
+	// internal/a/b/c_test.go true
+	// pkg/lib_test.go false
+	// cmd/main.go false
+}
+
+func TestPattern_Match(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    pathlib.PurePath
+		match   bool
+	}{
+		{"*.go", pathlib.File("main.go"), true},
+		{"*.go", pathlib.File("cmd/main.go"), false},
+		{"**/*.go", pathlib.File("main.go"), true},
+		{"**/*.go", pathlib.File("a/b/c/main.go"), true},
+		{"**/*.go", pathlib.File("/abs/main.go"), true},
+		{"a/**", pathlib.Dir("a"), true},
+		{"a/**/b", pathlib.Dir("a/b"), true},
+		{"a/**/b", pathlib.Dir("a/x/y/b"), true},
+		{"a/**/b", pathlib.Dir("a/x/y/c"), false},
+		{"./a/*", pathlib.File("a/b"), true},
+		{"*.{tar.gz,zip}", pathlib.File("archive.tar.gz"), true},
+		{"*.{tar.gz,zip}", pathlib.File("archive.zip"), true},
+		{"*.{tar.gz,zip}", pathlib.File("archive.tar"), false},
+		{"{a,b{c,d}}/x", pathlib.PathStr("bd/x"), true},
+		{"{a,b{c,d}}/x", pathlib.PathStr("b/x"), false},
+		{"/etc/*.conf", pathlib.File("/etc/app.conf"), true},
+		{"/etc/*.conf", pathlib.File("etc/app.conf"), false},
+		{`\{a\}`, pathlib.File("{a}"), true},
+		{"!*.go", pathlib.File("main.go"), true}, // Match ignores negation
+	}
+	for _, c := range cases {
+		p := expect(pathlib.CompilePattern(c.pattern))
+		if p.Match(c.path) != c.match {
+			t.Errorf("%q.Match(%q): expected %t", c.pattern, c.path, c.match)
+		}
+	}
+}
+
+func TestCompilePattern_bad(t *testing.T) {
+	for _, pattern := range []string{"[*", "{a,b", "a}", "**/[z-a"} {
+		if _, err := pathlib.CompilePattern(pattern); !errors.Is(err, filepath.ErrBadPattern) {
+			t.Errorf("%q: expected filepath.ErrBadPattern, got %v", pattern, err)
+		}
+	}
+}
+
+func TestPatterns_Match(t *testing.T) {
+	ps := expect(pathlib.CompilePatterns("**/*.go", "!vendor/**", "vendor/keep/*.go"))
+	cases := map[pathlib.File]bool{
+		"main.go":             true,
+		"vendor/lib/x.go":     false,
+		"vendor/keep/y.go":    true,
+		"README.md":           false,
+		"internal/vendor.go":  true,
+		"internal/vendor/z.o": false,
+	}
+	for path, expected := range cases {
+		if ps.Match(path) != expected {
+			t.Errorf("%q: expected %t", path, expected)
+		}
+	}
+}
+
+func TestDir_GlobSeq(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		for _, name := range []string{
+			"cmd/tool/main.go",
+			"cmd/tool/main_test.go",
+			"internal/x/y/z_test.go",
+			"pkg/lib_test.go",
+			"vendor/dep/dep_test.go",
+			"README.md",
+		} {
+			enforce(expect(temp.Join(name).AsFile().MakeAll(0o644, 0o755)).Close())
+		}
+		expect(temp.Join("node_modules/deep").AsDir().MakeAll(0o755, 0o755))
+
+		glob := func(patterns ...string) (matches []string) {
+			for match, err := range temp.GlobSeq(expect(pathlib.CompilePatterns(patterns...))...) {
+				enforce(err)
+				matches = append(matches, expect(match.Path().Rel(temp)).String())
+			}
+			return
+		}
+		cases := []struct {
+			patterns []string
+			expected []string
+		}{
+			{[]string{"{cmd,internal}/**/*_test.go"}, []string{"cmd/tool/main_test.go", "internal/x/y/z_test.go"}},
+			{[]string{"**/*_test.go", "!vendor/**"}, []string{"cmd/tool/main_test.go", "internal/x/y/z_test.go", "pkg/lib_test.go"}},
+			{[]string{"*"}, []string{"README.md", "cmd", "internal", "node_modules", "pkg", "vendor"}},
+			{[]string{"cmd/*"}, []string{"cmd/tool"}},
+			{[]string{"nothing/**"}, nil},
+		}
+		for _, c := range cases {
+			if actual := glob(c.patterns...); !slices.Equal(actual, c.expected) {
+				t.Errorf("%q:\nexpected %q\ngot      %q", c.patterns, c.expected, actual)
+			}
+		}
+
+		for match, err := range temp.GlobSeq(pathlib.MustCompilePattern("cmd/**")) {
+			enforce(err)
+			if _, isDir := match.Dir(); isDir != (match.Name() != "main.go" && match.Name() != "main_test.go") {
+				t.Errorf("unexpected kind %v for %q", match.Kind(), match.Path())
+			}
+		}
+
+		// stopping early works
+		for range temp.GlobSeq(pathlib.MustCompilePattern("**")) {
+			break
+		}
+	})
+}
+
+func TestDir_Glob_recursive(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	enforce(expect(temp.Join("a/b/c.txt").AsFile().MakeAll(0o644, 0o755)).Close())
+	enforce(expect(temp.Join("d.txt").AsFile().Make(0o644)).Close())
+	matches := expect(temp.Glob("**/*.txt"))
+	expected := []pathlib.PathStr{temp.Join("a/b/c.txt"), temp.Join("d.txt")}
+	if !slices.Equal(matches, expected) {
+		t.Errorf("expected %q\ngot      %q", expected, matches)
+	}
+}
+
+func TestDir_Glob_outside(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	writeFile(t, temp.Join("x/a.go").AsFile(), "")
+	writeFile(t, temp.Join("x/b.txt").AsFile(), "")
+	sub := expect(temp.Join("sub").AsDir().Make(0o755))
+
+	cases := map[string][]pathlib.PathStr{
+		"../x/*.go":    {temp.Join("x/a.go")},
+		"../x/a.go":    {temp.Join("x/a.go")},
+		"../x/missing": nil,
+		"../**/*.txt":  {temp.Join("x/b.txt")},
+		"/../x/*.txt":  {temp.Join("x/b.txt")}, // joined onto sub, like filepath.Glob
+	}
+	for pattern, expected := range cases {
+		if matches := expect(sub.Glob(pattern)); !slices.Equal(matches, expected) {
+			t.Errorf("%q: expected %q, got %q", pattern, expected, matches)
+		}
+	}
+	if _, err := sub.Glob("../[x"); !errors.Is(err, filepath.ErrBadPattern) {
+		t.Errorf("expected ErrBadPattern, got %v", err)
+	}
+}
+
+func TestDir_Glob_symlinks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("real/y.go").AsFile(), "")
+		writeFile(t, temp.Join("real/sub/x.go").AsFile(), "")
+		expect(temp.Join("link").AsSymlink().LinkTo("real"))
+		expect(temp.Join("real/loop").AsSymlink().LinkTo(".."))
+
+		cases := map[string][]pathlib.PathStr{
+			"*/y.go":       {temp.Join("link/y.go"), temp.Join("real/y.go")},
+			"*/*/x.go":     {temp.Join("link/sub/x.go"), temp.Join("real/sub/x.go")},
+			"link/sub/*":   {temp.Join("link/sub/x.go")},
+			"**/y.go":      {temp.Join("real/y.go")}, // "**" doesn't follow links
+			"real/loop/*":  {temp.Join("real/loop/link"), temp.Join("real/loop/real")},
+			"*/*/*":        {temp.Join("link/sub/x.go"), temp.Join("real/sub/x.go")}, // loops aren't followed
+			"link/**/x.go": {temp.Join("link/sub/x.go")},
+		}
+		for pattern, expected := range cases {
+			if matches := expect(temp.Glob(pattern)); !slices.Equal(matches, expected) {
+				t.Errorf("%q:\nexpected %q\ngot      %q", pattern, expected, matches)
+			}
+		}
+	})
+}
+
+func TestPatterns_onlyNegated(t *testing.T) {
+	ps := expect(pathlib.CompilePatterns("!*.tmp", "!build/**"))
+	cases := map[pathlib.File]bool{
+		"main.go":       true,
+		"a/b.go":        true,
+		"x.tmp":         false,
+		"build/out.bin": false,
+	}
+	for path, expected := range cases {
+		if ps.Match(path) != expected {
+			t.Errorf("%q: expected %t", path, expected)
+		}
+	}
+
+	temp := pathlib.Dir(t.TempDir())
+	writeFile(t, temp.Join("a.go").AsFile(), "")
+	writeFile(t, temp.Join("b.tmp").AsFile(), "")
+	if matches := expect(temp.Glob("!*.tmp")); !slices.Equal(matches, []pathlib.PathStr{temp.Join("a.go")}) {
+		t.Errorf("unexpected matches %q", matches)
+	}
+	var names []string
+	for entry, err := range temp.Entries(pathlib.EntriesOptions{Names: ps}) {
+		enforce(err)
+		names = append(names, entry.Name())
+	}
+	if !slices.Equal(names, []string{"a.go"}) {
+		t.Errorf("unexpected entries %q", names)
+	}
+}
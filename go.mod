@@ -1,4 +1,6 @@
 module github.com/skalt/pathlib.go
 
 // the minimum supported Go version is 1.24 due to the use of `testing.T.Chdir()`.
-go 1.24
+go 1.24.0
+
+require golang.org/x/sys v0.40.0
@@ -33,6 +33,15 @@ type PurePath interface {
 	IsLocal() bool
 }
 
+// The suffix-related parts of a path's final component. Every path type in this
+// package implements Stemmer alongside [PurePath].
+type Stemmer interface {
+	// The final component without its final suffix, e.g. "archive.tar" for "archive.tar.gz".
+	Stem() string
+	// The final component's suffixes, e.g. [".tar", ".gz"] for "archive.tar.gz".
+	Suffixes() []string
+}
+
 // transforms the appearance of a path, but not what it represents.
 type Transformer[P Kind] interface {
 	// Returns an absolute path, or an error if the path cannot be made absolute. Note that there may be more than one
@@ -59,6 +68,38 @@ type Transformer[P Kind] interface {
 	String() string
 }
 
+// Transformations that only depend on the path's string, not on the filesystem,
+// working directory or environment. Every path type in this package implements
+// PureTransformer, including [PosixPath] and [WindowsPath].
+type PureTransformer[P Kind] interface {
+	// Returns a relative path to the target directory, or an error if the path cannot be made relative.
+	//
+	// See [path/filepath.Rel].
+	Rel(target Dir) (P, error)
+	// See [path/filepath.Localize].
+	Localize() (P, error)
+	// Remove ".", "..", and repeated slashes from a path.
+	//
+	// See [path/filepath.Clean].
+	Clean() P
+	// Returns true if the two paths represent the same path.
+	Eq(other P) bool
+	// Replace the final component.
+	WithName(name string) (P, error)
+	// Replace the final component, keeping its final suffix.
+	WithStem(stem string) (P, error)
+	// Replace or remove the final component's final suffix.
+	WithSuffix(suffix string) (P, error)
+	// Returns the path relative to the directory without consulting the filesystem or
+	// the working directory.
+	RelativeTo(base Dir) (P, error)
+	// Returns true if the path is lexically beneath the directory, or is the directory.
+	IsRelativeTo(base Dir) bool
+
+	// Convenience method to cast get the untyped string representation of the path.
+	String() string
+}
+
 // An observation of a path on-disk, including a constant observation timestamp.
 type Info[P Kind] interface {
 	fs.FileInfo
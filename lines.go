@@ -0,0 +1,140 @@
+package pathlib
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Options that control [File.Lines] and [FileHandle.Lines].
+type LineOptions struct {
+	// The longest line, in bytes, that can be yielded. Longer lines stop iteration
+	// with [bufio.ErrTooLong]. Defaults to [bufio.MaxScanTokenSize].
+	MaxLength int
+	// Keep the trailing "\r" of "\r\n" line endings.
+	KeepCR bool
+}
+
+// Lazily yield each line of the file without its line ending. The file is opened
+// when iteration starts and closed when it ends, even if the loop exits early.
+func (f File) Lines(opts LineOptions) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		h, err := openFile(f, os.O_RDONLY, 0)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		defer func() { _ = h.Close() }()
+		h.Lines(opts)(yield)
+	}
+}
+
+// Lazily yield the file's contents in chunks of up to size bytes, which must be
+// positive. The yielded slice is reused, so it is only valid until the next
+// iteration. The file is opened when iteration starts and closed when it ends, even
+// if the loop exits early.
+func (f File) Chunks(size int) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		if size <= 0 {
+			yield(nil, &fs.PathError{Op: "read", Path: string(f), Err: syscall.EINVAL})
+			return
+		}
+		h, err := openFile(f, os.O_RDONLY, 0)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = h.Close() }()
+		buf := make([]byte, size)
+		for {
+			n, err := io.ReadFull(h, buf)
+			if n > 0 && !yield(buf[:n], nil) {
+				return
+			}
+			switch err {
+			case nil:
+				continue
+			case io.EOF, io.ErrUnexpectedEOF:
+				return
+			default:
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// Lazily yield each line from the handle's current offset, without line endings.
+// The handle is left open. If the loop exits early, the handle is seeked back to just
+// after the last yielded line so that it can keep being read; this is skipped for
+// handles that can't seek, which are left past the read-ahead buffer.
+//
+// Lines implements [FileHandle].
+func (h *handle) Lines(opts LineOptions) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		r := &countingReader{r: h}
+		scanner := bufio.NewScanner(r)
+		if opts.MaxLength > 0 {
+			// the buffer must also fit the line ending
+			scanner.Buffer(nil, opts.MaxLength+2)
+		}
+		split := bufio.ScanLines
+		if opts.KeepCR {
+			split = scanRawLines
+		}
+		var consumed int64
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			advance, token, err := split(data, atEOF)
+			consumed += int64(advance)
+			return advance, token, err
+		})
+		for scanner.Scan() {
+			line := scanner.Text()
+			length := len(line)
+			if opts.KeepCR && strings.HasSuffix(line, "\r") {
+				length-- // part of the line ending
+			}
+			if opts.MaxLength > 0 && length > opts.MaxLength {
+				yield("", bufio.ErrTooLong)
+				return
+			}
+			if !yield(line, nil) {
+				// give back what the scanner read ahead
+				_, _ = h.Seek(consumed-r.n, io.SeekCurrent)
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// like [bufio.ScanLines], but keeps carriage returns.
+func scanRawLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for i, b := range data {
+		if b == '\n' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
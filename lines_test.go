@@ -0,0 +1,176 @@
+package pathlib_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleFile_Lines() {
+	log := pathlib.TempDir().Join("lines-example.log").AsFile()
+	defer func() { _ = log.Remove() }()
+	expect(log.WriteString("INFO starting\r\nERROR disk full\nINFO done\n", 0o644))
+
+	for line, err := range log.Lines(pathlib.LineOptions{}) {
+		enforce(err)
+		if len(line) >= 5 && line[:5] == "ERROR" {
+			fmt.Printf("%q\n", line)
+			break // closes the file
+		}
+	}
+	// Output:
+	// "ERROR disk full"
+}
+
+// closingFS records which files are still open.
+type closingFS struct {
+	*pathlib.MemFS
+	open map[string]int
+}
+
+type closingFile struct {
+	pathlib.RawFile
+	fsys *closingFS
+}
+
+func (c *closingFS) OpenFile(name string, flag int, perm fs.FileMode) (pathlib.RawFile, error) {
+	f, err := c.MemFS.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	c.open[name]++
+	return closingFile{f, c}, nil
+}
+
+func (f closingFile) Close() error {
+	f.fsys.open[f.Name()]--
+	return f.RawFile.Close()
+}
+
+func TestFile_Lines(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file.txt").AsFile().WriteString("a\r\nb\n\nc", 0o644))
+		var lines []string
+		for line, err := range file.Lines(pathlib.LineOptions{}) {
+			enforce(err)
+			lines = append(lines, line)
+		}
+		if expected := []string{"a", "b", "", "c"}; !slices.Equal(lines, expected) {
+			t.Errorf("expected %q, got %q", expected, lines)
+		}
+
+		lines = nil
+		for line, err := range file.Lines(pathlib.LineOptions{KeepCR: true}) {
+			enforce(err)
+			lines = append(lines, line)
+		}
+		if expected := []string{"a\r", "b", "", "c"}; !slices.Equal(lines, expected) {
+			t.Errorf("expected %q, got %q", expected, lines)
+		}
+
+		long := expect(temp.Join("long.txt").AsFile().WriteString("ok\ntoo long\nunreached\n", 0o644))
+		lines = nil
+		var err error
+		for line, lineErr := range long.Lines(pathlib.LineOptions{MaxLength: 4}) {
+			if lineErr != nil {
+				err = lineErr
+				continue
+			}
+			lines = append(lines, line)
+		}
+		if !errors.Is(err, bufio.ErrTooLong) || !slices.Equal(lines, []string{"ok"}) {
+			t.Errorf("expected bufio.ErrTooLong after %q, got %v after %q", []string{"ok"}, err, lines)
+		}
+
+		// the "\r" of a line ending doesn't count towards the limit
+		exact := expect(temp.Join("exact.txt").AsFile().WriteString("abcd\r\nefgh\r\n", 0o644))
+		lines = nil
+		for line, err := range exact.Lines(pathlib.LineOptions{MaxLength: 4, KeepCR: true}) {
+			enforce(err)
+			lines = append(lines, line)
+		}
+		if expected := []string{"abcd\r", "efgh\r"}; !slices.Equal(lines, expected) {
+			t.Errorf("expected %q, got %q", expected, lines)
+		}
+
+		for _, err := range temp.Join("missing.txt").AsFile().Lines(pathlib.LineOptions{}) {
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("expected fs.ErrNotExist, got %v", err)
+			}
+		}
+	})
+}
+
+func TestFile_Lines_earlyExit(t *testing.T) {
+	dir := pathlib.Dir("/closing").Join(t.Name()).AsDir()
+	fsys := &closingFS{pathlib.NewMemFS(), map[string]int{}}
+	enforce(fsys.MkdirAll(dir.String(), 0o755))
+	t.Cleanup(pathlib.Bind(dir, fsys))
+	file := expect(dir.Join("file.txt").AsFile().WriteString("1\n2\n3\n", 0o644))
+
+	for range file.Lines(pathlib.LineOptions{}) {
+		break
+	}
+	for range file.Chunks(1) {
+		break
+	}
+	if n := fsys.open[file.String()]; n != 0 {
+		t.Errorf("expected the file to be closed, %d handles are open", n)
+	}
+}
+
+func TestFile_Chunks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file.bin").AsFile().WriteString("abcdefg", 0o644))
+		var chunks []string
+		for chunk, err := range file.Chunks(3) {
+			enforce(err)
+			chunks = append(chunks, string(chunk))
+		}
+		if expected := []string{"abc", "def", "g"}; !slices.Equal(chunks, expected) {
+			t.Errorf("expected %q, got %q", expected, chunks)
+		}
+		for _, err := range file.Chunks(0) {
+			if !errors.Is(err, syscall.EINVAL) {
+				t.Errorf("expected EINVAL, got %v", err)
+			}
+		}
+	})
+}
+
+func TestFileHandle_Lines(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file.txt").AsFile().WriteString("header\nrow 1\nrow 2\n", 0o644))
+		h := expect(file.Open(os.O_RDONLY, 0))
+		defer func() { enforce(h.Close()) }()
+		expect(h.Seek(int64(len("header\n")), io.SeekStart))
+		var rows []string
+		for line, err := range h.Lines(pathlib.LineOptions{}) {
+			enforce(err)
+			rows = append(rows, line)
+		}
+		if expected := []string{"row 1", "row 2"}; !slices.Equal(rows, expected) {
+			t.Errorf("expected %q, got %q", expected, rows)
+		}
+
+		// breaking early leaves the offset just after the last yielded line
+		expect(h.Seek(0, io.SeekStart))
+		for line, err := range h.Lines(pathlib.LineOptions{}) {
+			enforce(err)
+			if line == "header" {
+				break
+			}
+		}
+		if rest := string(expect(io.ReadAll(h))); rest != "row 1\nrow 2\n" {
+			t.Errorf("expected the rest after the header, got %q", rest)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Implemented by [Filesystem] backends that support hard links. Linking paths bound
+// to backends that don't implement it fails with [errors.ErrUnsupported].
+type HardLinkFilesystem interface {
+	// See [os.Link].
+	Link(oldName, newName string) error
+}
+
+var _ HardLinkFilesystem = OS{}
+
+// Link implements [HardLinkFilesystem].
+func (OS) Link(oldName, newName string) error { return os.Link(oldName, newName) }
+
+// Create a hard link at f to the existing file target, so that both names refer to
+// the same file. Unlike [Symlink.LinkTo], the link keeps the file's contents alive
+// after target is removed. Linking across [Filesystem] backends fails with EXDEV.
+//
+// See [os.Link].
+func (f File) HardLinkTo(target File) (File, error) {
+	if !sameBinding(string(target), string(f)) {
+		// linking between backends is like linking across devices
+		return f, &os.LinkError{Op: "link", Old: string(target), New: string(f), Err: errCrossDevice}
+	}
+	fsys, ok := filesystemOf(string(f)).(HardLinkFilesystem)
+	if !ok {
+		return f, &os.LinkError{Op: "link", Old: string(target), New: string(f), Err: errors.ErrUnsupported}
+	}
+	return f, fsys.Link(string(target), string(f))
+}
+
+// The identity of a file on-disk: its device and inode numbers. Two paths with equal
+// FileIDs are hard links to the same file, so FileIDs can be used as map keys to
+// deduplicate paths. FileIDs from a [MemFS] never equal FileIDs from other backends.
+type FileID struct {
+	Device uint64
+	Inode  uint64
+
+	mem bool // MemFS device numbers may collide with real ones
+}
+
+// An [Info] that knows the identity of the observed file. Every [Info] returned by
+// this package implements Identifier.
+type Identifier interface {
+	// Returns the observed file's device and inode numbers, if the backend reports them.
+	ID() (FileID, bool)
+}
+
+var _ Identifier = onDisk[PathStr]{}
+
+// Returns the identity of the observed file, if the backend reports one.
+func fileIDOf(info fs.FileInfo) (FileID, bool) {
+	if w, ok := info.(interface{ unwrap() fs.FileInfo }); ok {
+		info = w.unwrap()
+	}
+	dev, ino, ok := inodeOf(info)
+	if !ok {
+		return FileID{}, false
+	}
+	_, mem := info.Sys().(*MemStat)
+	return FileID{Device: dev, Inode: ino, mem: mem}, true
+}
+
+// ID implements [Identifier]. On Windows, only files in a [MemFS] have IDs; use
+// [FileHandle.ID] instead.
+func (p onDisk[P]) ID() (FileID, bool) {
+	return fileIDOf(p.FileInfo)
+}
+
+// ID implements [FileHandle].
+func (h *handle) ID() (FileID, error) {
+	info, err := h.RawFile.Stat()
+	if err != nil {
+		return FileID{}, err
+	}
+	if id, ok := fileIDOf(info); ok {
+		return id, nil
+	}
+	return fileIDOfHandle(h.RawFile)
+}
+
+// Returns true if a and b refer to the same file on-disk after following symlinks,
+// as with [os.SameFile]. Unlike [PathStr.Eq], this recognizes hard links and paths
+// through symlinked directories.
+func SameFile[A, B Kind](a A, b B) (bool, error) {
+	x, err := stat(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := stat(b)
+	if err != nil {
+		return false, err
+	}
+	return sameFile(x, y), nil
+}
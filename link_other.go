@@ -0,0 +1,12 @@
+//go:build !windows
+
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+)
+
+func fileIDOfHandle(f RawFile) (FileID, error) {
+	return FileID{}, &fs.PathError{Op: "fstat", Path: f.Name(), Err: errors.ErrUnsupported}
+}
@@ -0,0 +1,140 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleSameFile() {
+	dir := pathlib.Dir(expect(os.MkdirTemp("", "")))
+	defer func() { _, _ = dir.RemoveAll() }()
+
+	original := expect(dir.Join("original.txt").AsFile().WriteString("hello", 0o644))
+	link := expect(dir.Join("link.txt").AsFile().HardLinkTo(original))
+
+	fmt.Println(link.Eq(original))
+	fmt.Println(expect(pathlib.SameFile(link, original)))
+	// Output:
+	// false
+	// true
+}
+
+func TestFile_HardLinkTo(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		original := expect(temp.Join("original.txt").AsFile().WriteString("hello", 0o644))
+		link := expect(temp.Join("link.txt").AsFile().HardLinkTo(original))
+
+		a, aOK := expect(link.Stat()).(pathlib.Identifier).ID()
+		b, bOK := expect(original.Stat()).(pathlib.Identifier).ID()
+		if !aOK || !bOK || a != b {
+			t.Errorf("expected equal IDs, got %v, %v", a, b)
+		}
+
+		if _, err := link.HardLinkTo(original); !errors.Is(err, os.ErrExist) {
+			t.Errorf("expected os.ErrExist, got %v", err)
+		}
+		if _, err := temp.Join("dir-link").AsFile().HardLinkTo(pathlib.File(temp)); err == nil {
+			t.Error("expected an error linking to a directory")
+		}
+
+		enforce(original.Remove())
+		if content := expect(link.ReadString()); content != "hello" {
+			t.Errorf("expected the link to keep the contents, got %q", content)
+		}
+	})
+}
+
+func TestFile_HardLinkTo_acrossBackends(t *testing.T) {
+	mem := memDir(t)
+	original := expect(pathlib.Dir(t.TempDir()).Join("original").AsFile().WriteString("", 0o644))
+	_, err := mem.Join("link").AsFile().HardLinkTo(original)
+	if !errors.Is(err, errCrossDevice) {
+		t.Errorf("expected EXDEV, got %v", err)
+	}
+
+	fsys := struct{ pathlib.Filesystem }{pathlib.OS{}}
+	unbind := pathlib.Bind("/unsupported", fsys)
+	defer unbind()
+	_, err = pathlib.File("/unsupported/b").HardLinkTo("/unsupported/a")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected errors.ErrUnsupported, got %v", err)
+	}
+}
+
+func TestFile_HardLinkTo_uncomparableBackend(t *testing.T) {
+	bound := pathlib.Dir(t.TempDir()).Join("bound").AsDir()
+	defer pathlib.Bind(bound, uncomparableFS{tags: map[string]string{}})()
+	original := writeFile(t, bound.Join("original").AsFile(), "hello")
+	link := expect(bound.Join("link").AsFile().HardLinkTo(original))
+	if !expect(pathlib.SameFile(link, original)) {
+		t.Error("expected the link and original to be the same file")
+	}
+}
+
+func TestSameFile(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		file := expect(dir.Join("file").AsFile().WriteString("", 0o644))
+		other := expect(dir.Join("other").AsFile().WriteString("", 0o644))
+		link := expect(temp.Join("link").AsSymlink().LinkTo("dir"))
+
+		cases := []struct {
+			a, b pathlib.PathStr
+			same bool
+		}{
+			{pathlib.PathStr(file), pathlib.PathStr(file), true},
+			{pathlib.PathStr(file), link.Join("file"), true},
+			{pathlib.PathStr(dir), pathlib.PathStr(link), true},
+			{pathlib.PathStr(file), pathlib.PathStr(other), false},
+			{pathlib.PathStr(dir), pathlib.PathStr(file), false},
+		}
+		for _, c := range cases {
+			if same := expect(pathlib.SameFile(c.a, c.b)); same != c.same {
+				t.Errorf("SameFile(%q, %q): expected %v, got %v", c.a, c.b, c.same, same)
+			}
+		}
+		if _, err := pathlib.SameFile(file, dir.Join("missing")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected os.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestFileID(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file").AsFile().WriteString("", 0o644))
+		h := expect(file.Open(os.O_RDONLY, 0))
+		defer func() { enforce(h.Close()) }()
+		before := expect(h.ID())
+
+		renamed := expect(file.Rename(temp.Join("renamed")))
+		if id, _ := expect(renamed.Stat()).(pathlib.Identifier).ID(); id != before {
+			t.Errorf("expected the ID to survive renaming, got %v and %v", before, id)
+		}
+		if after := expect(h.ID()); after != before {
+			t.Errorf("expected the handle's ID to be stable, got %v and %v", before, after)
+		}
+
+		seen := map[pathlib.FileID]bool{before: true}
+		other := expect(temp.Join("other").AsFile().WriteString("", 0o644))
+		if id, _ := expect(other.Stat()).(pathlib.Identifier).ID(); seen[id] {
+			t.Errorf("expected distinct files to have distinct IDs")
+		}
+	})
+}
+
+func TestFileID_memDistinctFromOS(t *testing.T) {
+	osFile := expect(pathlib.Dir(t.TempDir()).Join("file").AsFile().WriteString("", 0o644))
+	memFile := expect(memDir(t).Join("file").AsFile().WriteString("", 0o644))
+	osID, _ := expect(osFile.Stat()).(pathlib.Identifier).ID()
+	memID, _ := expect(memFile.Stat()).(pathlib.Identifier).ID()
+	if osID == memID {
+		t.Errorf("expected distinct IDs, got %v", osID)
+	}
+	if expect(pathlib.SameFile(osFile, memFile)) {
+		t.Error("expected files in different backends to differ")
+	}
+}
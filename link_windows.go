@@ -0,0 +1,21 @@
+package pathlib
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// reads the volume serial number and file index, which [os.File.Stat] doesn't expose.
+func fileIDOfHandle(f RawFile) (FileID, error) {
+	var d syscall.ByHandleFileInformation
+	err := control(f, func(fd uintptr) error {
+		return syscall.GetFileInformationByHandle(syscall.Handle(fd), &d)
+	})
+	if err != nil {
+		return FileID{}, &fs.PathError{Op: "GetFileInformationByHandle", Path: f.Name(), Err: err}
+	}
+	return FileID{
+		Device: uint64(d.VolumeSerialNumber),
+		Inode:  uint64(d.FileIndexHigh)<<32 | uint64(d.FileIndexLow),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package pathlib
+
+import (
+	"context"
+	"io/fs"
+	"time"
+)
+
+// How long [FileHandle.LockContext] first waits before retrying a contended lock.
+// The wait doubles after each attempt, up to lockPollMax.
+const (
+	lockPollMin = time.Millisecond
+	lockPollMax = 250 * time.Millisecond
+)
+
+// Lock implements [FileHandle].
+func (h *handle) Lock(exclusive bool) error {
+	_, err := h.lock("flock", func() (bool, error) { return lockFile(h.RawFile, exclusive, true) })
+	return err
+}
+
+// TryLock implements [FileHandle].
+func (h *handle) TryLock(exclusive bool) (bool, error) {
+	return h.lock("flock", func() (bool, error) { return lockFile(h.RawFile, exclusive, false) })
+}
+
+// LockContext implements [FileHandle].
+func (h *handle) LockContext(ctx context.Context, exclusive bool) error {
+	return h.poll(ctx, func() (bool, error) { return h.TryLock(exclusive) })
+}
+
+// Unlock implements [FileHandle].
+func (h *handle) Unlock() error {
+	_, err := h.lock("flock", func() (bool, error) { return true, unlockFile(h.RawFile) })
+	return err
+}
+
+// LockRange implements [FileHandle].
+func (h *handle) LockRange(offset, length int64, exclusive bool) error {
+	_, err := h.lock("fcntl", func() (bool, error) { return lockRange(h.RawFile, offset, length, exclusive, true) })
+	return err
+}
+
+// TryLockRange implements [FileHandle].
+func (h *handle) TryLockRange(offset, length int64, exclusive bool) (bool, error) {
+	return h.lock("fcntl", func() (bool, error) { return lockRange(h.RawFile, offset, length, exclusive, false) })
+}
+
+// LockRangeContext implements [FileHandle].
+func (h *handle) LockRangeContext(ctx context.Context, offset, length int64, exclusive bool) error {
+	return h.poll(ctx, func() (bool, error) { return h.TryLockRange(offset, length, exclusive) })
+}
+
+// UnlockRange implements [FileHandle].
+func (h *handle) UnlockRange(offset, length int64) error {
+	_, err := h.lock("fcntl", func() (bool, error) { return true, unlockRange(h.RawFile, offset, length) })
+	return err
+}
+
+// wraps errors from a locking operation with the handle's path.
+func (h *handle) lock(op string, fn func() (bool, error)) (bool, error) {
+	ok, err := fn()
+	if err != nil {
+		return false, &fs.PathError{Op: op, Path: h.Name(), Err: err}
+	}
+	return ok, nil
+}
+
+// retries tryLock with exponential backoff until it succeeds, fails, or ctx is done.
+func (h *handle) poll(ctx context.Context, tryLock func() (bool, error)) error {
+	for delay := lockPollMin; ; delay = min(2*delay, lockPollMax) {
+		if ok, err := tryLock(); ok || err != nil {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// runs fn with the file's descriptor.
+func control(f RawFile, fn func(fd uintptr) error) error {
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fnErr error
+	if err = conn.Control(func(fd uintptr) { fnErr = fn(fd) }); err != nil {
+		return err
+	}
+	return fnErr
+}
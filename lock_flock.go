@@ -0,0 +1,42 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package pathlib
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// Acquires a flock(2) lock. If wait is false, returns false instead of blocking
+// when another open file description holds a conflicting lock.
+func lockFile(f RawFile, exclusive, wait bool) (bool, error) {
+	how := unix.LOCK_SH
+	if exclusive {
+		how = unix.LOCK_EX
+	}
+	if !wait {
+		how |= unix.LOCK_NB
+	}
+	err := control(f, func(fd uintptr) error {
+		return retryEINTR(func() error { return unix.Flock(int(fd), how) })
+	})
+	if errors.Is(err, unix.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFile(f RawFile) error {
+	return control(f, func(fd uintptr) error {
+		return retryEINTR(func() error { return unix.Flock(int(fd), unix.LOCK_UN) })
+	})
+}
+
+func retryEINTR(fn func() error) error {
+	for {
+		if err := fn(); err != unix.EINTR {
+			return err
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package pathlib
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/sys/unix"
+)
+
+// Acquires an open file description lock on length bytes from offset, or to the end
+// of the file if length is 0. If wait is false, returns false instead of blocking
+// when another open file description holds a conflicting lock.
+func lockRange(f RawFile, offset, length int64, exclusive, wait bool) (bool, error) {
+	lockType := int16(unix.F_RDLCK)
+	if exclusive {
+		lockType = unix.F_WRLCK
+	}
+	cmd := unix.F_OFD_SETLK
+	if wait {
+		cmd = unix.F_OFD_SETLKW
+	}
+	err := setOFDLock(f, cmd, lockType, offset, length)
+	if !wait && (errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EACCES)) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockRange(f RawFile, offset, length int64) error {
+	return setOFDLock(f, unix.F_OFD_SETLK, unix.F_UNLCK, offset, length)
+}
+
+func setOFDLock(f RawFile, cmd int, lockType int16, offset, length int64) error {
+	lock := unix.Flock_t{
+		Type:   lockType,
+		Whence: io.SeekStart,
+		Start:  offset,
+		Len:    length,
+		// OFD locks require a zero pid
+	}
+	return control(f, func(fd uintptr) error {
+		return retryEINTR(func() error { return unix.FcntlFlock(fd, cmd, &lock) })
+	})
+}
@@ -0,0 +1,34 @@
+package pathlib_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFileHandle_LockRange(t *testing.T) {
+	a, b := twoHandles(t)
+	enforce(a.LockRange(0, 10, true))
+	if !expect(b.TryLockRange(10, 10, true)) {
+		t.Error("disjoint ranges shouldn't conflict")
+	}
+	if expect(b.TryLockRange(5, 1, false)) {
+		t.Error("expected a conflict with the exclusively locked range")
+	}
+	if !expect(b.TryLock(true)) {
+		t.Error("range locks shouldn't interact with whole-file locks")
+	}
+
+	enforce(a.UnlockRange(0, 6))
+	if !expect(b.TryLockRange(5, 1, false)) {
+		t.Error("expected the unlocked bytes to be available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.LockRangeContext(ctx, 6, 4, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	enforce(a.UnlockRange(0, 0))
+	enforce(b.LockRange(0, 0, true))
+}
@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package pathlib
+
+import "errors"
+
+func lockFile(RawFile, bool, bool) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func unlockFile(RawFile) error {
+	return errors.ErrUnsupported
+}
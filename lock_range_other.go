@@ -0,0 +1,14 @@
+//go:build !linux
+
+package pathlib
+
+import "errors"
+
+// Open file description locks are Linux-specific.
+func lockRange(RawFile, int64, int64, bool, bool) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func unlockRange(RawFile, int64, int64) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,72 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows
+
+package pathlib_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// opens the file twice, so that the handles have separate open file descriptions.
+func twoHandles(t *testing.T) (a, b pathlib.FileHandle) {
+	t.Helper()
+	file := expect(pathlib.Dir(t.TempDir()).Join("state.json").AsFile().WriteString("{}", 0o644))
+	a = expect(file.Open(os.O_RDWR, 0))
+	b = expect(file.Open(os.O_RDWR, 0))
+	t.Cleanup(func() {
+		_ = a.Close()
+		_ = b.Close()
+	})
+	return
+}
+
+func TestFileHandle_Lock(t *testing.T) {
+	a, b := twoHandles(t)
+	enforce(a.Lock(true))
+	if expect(b.TryLock(false)) {
+		t.Error("a shared lock shouldn't be granted while an exclusive lock is held")
+	}
+	enforce(a.Lock(false)) // downgrade
+	if !expect(b.TryLock(false)) {
+		t.Error("shared locks shouldn't conflict")
+	}
+	if expect(a.TryLock(true)) {
+		t.Error("an exclusive lock shouldn't be granted while another handle holds a shared lock")
+	}
+	enforce(b.Unlock())
+	if !expect(a.TryLock(true)) {
+		t.Error("expected the exclusive lock after the shared lock was released")
+	}
+}
+
+func TestFileHandle_LockContext(t *testing.T) {
+	a, b := twoHandles(t)
+	enforce(a.Lock(true))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.LockContext(ctx, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		enforce(a.Close()) // releases the lock
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	enforce(b.LockContext(ctx, true))
+}
+
+func TestFileHandle_Lock_unsupported(t *testing.T) {
+	file := expect(memDir(t).Join("file.txt").AsFile().WriteString("", 0o644))
+	h := expect(file.Open(os.O_RDWR, 0))
+	defer func() { enforce(h.Close()) }()
+	if err := h.Lock(true); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected errors.ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package pathlib
+
+import (
+	"errors"
+	"math"
+
+	"golang.org/x/sys/windows"
+)
+
+// Acquires a LockFileEx lock on the whole file. If wait is false, returns false
+// instead of blocking when another handle holds a conflicting lock.
+func lockFile(f RawFile, exclusive, wait bool) (bool, error) {
+	var flags uint32
+	if exclusive {
+		flags |= windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	if !wait {
+		flags |= windows.LOCKFILE_FAIL_IMMEDIATELY
+	}
+	err := control(f, func(fd uintptr) error {
+		// Windows stacks locks, so release any lock this handle holds first, the way
+		// flock(2) converts one.
+		if err := unlockHandle(windows.Handle(fd)); err != nil {
+			return err
+		}
+		return windows.LockFileEx(windows.Handle(fd), flags, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+	})
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFile(f RawFile) error {
+	return control(f, func(fd uintptr) error { return unlockHandle(windows.Handle(fd)) })
+}
+
+// Like flock(2), unlocking a handle that holds no lock isn't an error.
+func unlockHandle(h windows.Handle) error {
+	err := windows.UnlockFileEx(h, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+	if errors.Is(err, windows.ERROR_NOT_LOCKED) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,214 @@
+package pathlib
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+)
+
+var (
+	_ encoding.TextMarshaler   = PathStr("")
+	_ encoding.TextUnmarshaler = (*PathStr)(nil)
+	_ encoding.TextMarshaler   = Dir("")
+	_ encoding.TextUnmarshaler = (*Dir)(nil)
+	_ encoding.TextMarshaler   = File("")
+	_ encoding.TextUnmarshaler = (*File)(nil)
+	_ encoding.TextMarshaler   = Symlink("")
+	_ encoding.TextUnmarshaler = (*Symlink)(nil)
+)
+
+// MarshalText implements [encoding.TextMarshaler].
+func (p PathStr) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// Stores the text as-is. See [DecodeOptions] to expand, resolve or check decoded paths.
+//
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (p *PathStr) UnmarshalText(text []byte) error {
+	*p = PathStr(text)
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (d Dir) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// See [PathStr.UnmarshalText].
+//
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (d *Dir) UnmarshalText(text []byte) error {
+	*d = Dir(text)
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (f File) MarshalText() ([]byte, error) {
+	return []byte(f), nil
+}
+
+// See [PathStr.UnmarshalText].
+//
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (f *File) UnmarshalText(text []byte) error {
+	*f = File(text)
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (s Symlink) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// See [PathStr.UnmarshalText].
+//
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (s *Symlink) UnmarshalText(text []byte) error {
+	*s = Symlink(text)
+	return nil
+}
+
+// Opt-in processing for paths read from configuration. Use [Decode] on single values,
+// [DecodeOptions.Apply] after unmarshalling a struct, or [DecodeOptions.Hook] with
+// decoders that accept mapstructure-style hooks.
+type DecodeOptions struct {
+	// Expand a leading "~" into the current user's home directory. See [PathStr.ExpandUser].
+	ExpandUser bool
+	// If non-empty, relative paths are joined onto Base and made absolute, e.g. so
+	// that paths in a config file are relative to the file's directory.
+	Base Dir
+	// Check that the path exists with the right type on-disk: [Dir.Stat], [File.Stat]
+	// and [Symlink.Lstat] fail with [WrongTypeOnDisk], while [PathStr] only needs to exist.
+	Stat bool
+}
+
+// Applies the options to a decoded path. Empty paths are returned unchanged unless
+// opts.Stat is set.
+func Decode[P Kind](text string, opts DecodeOptions) (result P, err error) {
+	result = P(text)
+	if result != "" && opts.ExpandUser {
+		if result, err = expandUser(result); err != nil {
+			return
+		}
+	}
+	if result != "" && opts.Base != "" && !result.IsAbsolute() {
+		if result, err = abs(P(opts.Base.Join(string(result)))); err != nil {
+			return
+		}
+	}
+	if opts.Stat {
+		err = checkOnDisk(result)
+	}
+	return
+}
+
+// checks that p exists with the type it claims to have.
+func checkOnDisk[P Kind](p P) (err error) {
+	switch p := any(p).(type) {
+	case Symlink:
+		_, err = p.Lstat()
+	case Beholder[P]:
+		_, err = p.Stat()
+	}
+	return
+}
+
+func decodeAny[P Kind](text string, opts DecodeOptions) (any, error) {
+	return Decode[P](text, opts)
+}
+
+var decoders = map[reflect.Type]func(string, DecodeOptions) (any, error){
+	reflect.TypeFor[PathStr](): decodeAny[PathStr],
+	reflect.TypeFor[Dir]():     decodeAny[Dir],
+	reflect.TypeFor[File]():    decodeAny[File],
+	reflect.TypeFor[Symlink](): decodeAny[Symlink],
+}
+
+// Returns a hook with the signature of mapstructure's DecodeHookFuncType that decodes
+// strings into [PathStr], [Dir], [File] and [Symlink] values using the options.
+func (o DecodeOptions) Hook() func(from, to reflect.Type, data any) (any, error) {
+	return func(from, to reflect.Type, data any) (any, error) {
+		decode, ok := decoders[to]
+		if !ok || from.Kind() != reflect.String {
+			return data, nil
+		}
+		return decode(reflect.ValueOf(data).String(), o)
+	}
+}
+
+var errNotPointer = errors.New("pathlib: Apply requires a non-nil pointer")
+
+// Re-decodes every [PathStr], [Dir], [File] and [Symlink] reachable from v through
+// exported struct fields, pointers, interfaces, slices, arrays and map values,
+// stopping at the first error. v must be a non-nil pointer, e.g. to a struct filled by
+// [encoding/json.Unmarshal].
+func (o DecodeOptions) Apply(v any) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return errNotPointer
+	}
+	return o.apply(value, map[visit]bool{})
+}
+
+// A pointer that [DecodeOptions.Apply] has followed. The type is part of the key
+// because a pointer to a struct and a pointer to its first field are equal.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (o DecodeOptions) apply(v reflect.Value, seen map[visit]bool) error {
+	if decode, ok := decoders[v.Type()]; ok {
+		result, err := decode(v.String(), o)
+		if err == nil {
+			v.Set(reflect.ValueOf(result))
+		}
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		key := visit{v.Pointer(), v.Type()}
+		if v.IsNil() || seen[key] {
+			return nil
+		}
+		seen[key] = true
+		return o.apply(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// the dynamic value isn't settable, so decode a copy and store it back
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := o.apply(elem, seen); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				if err := o.apply(v.Field(i), seen); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := o.apply(v.Index(i), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if err := o.apply(elem, seen); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	}
+	return nil
+}
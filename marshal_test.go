@@ -0,0 +1,185 @@
+package pathlib_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDecodeOptions_Apply() {
+	var config struct {
+		Data  pathlib.Dir
+		Log   pathlib.File
+		Cache *pathlib.Dir
+	}
+	enforce(json.Unmarshal([]byte(`{"Data": "data", "Log": "/var/log/app.log", "Cache": "cache"}`), &config))
+	enforce(pathlib.DecodeOptions{Base: "/etc/app"}.Apply(&config))
+	fmt.Println(config.Data, config.Log, *config.Cache)
+	// Output:
+	// /etc/app/data /var/log/app.log /etc/app/cache
+}
+
+func TestMarshalText(t *testing.T) {
+	type config struct {
+		P pathlib.PathStr
+		D pathlib.Dir
+		F pathlib.File
+		S pathlib.Symlink
+		M map[pathlib.File]pathlib.Dir
+	}
+	in := config{"a", "b/", "~/c", "d", map[pathlib.File]pathlib.Dir{"e": "f"}}
+	data := expect(json.Marshal(in))
+	if string(data) != `{"P":"a","D":"b/","F":"~/c","S":"d","M":{"e":"f"}}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+	var out config
+	enforce(json.Unmarshal(data, &out))
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("expected %v, got %v", in, out)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	home := expect(pathlib.UserHomeDir())
+	base := pathlib.Dir("/etc/app")
+	cases := []struct {
+		text     string
+		opts     pathlib.DecodeOptions
+		expected pathlib.PathStr
+	}{
+		{"~/a", pathlib.DecodeOptions{}, "~/a"},
+		{"~/a", pathlib.DecodeOptions{ExpandUser: true}, home.Join("a")},
+		{"~a", pathlib.DecodeOptions{ExpandUser: true}, "~a"},
+		{"a/../b", pathlib.DecodeOptions{Base: base}, "/etc/app/b"},
+		{"/a", pathlib.DecodeOptions{Base: base}, "/a"},
+		{"~/a", pathlib.DecodeOptions{ExpandUser: true, Base: base}, home.Join("a")},
+		{"", pathlib.DecodeOptions{ExpandUser: true, Base: base}, ""},
+	}
+	for _, c := range cases {
+		if actual := expect(pathlib.Decode[pathlib.PathStr](c.text, c.opts)); actual != c.expected {
+			t.Errorf("Decode(%q, %+v): expected %q, got %q", c.text, c.opts, c.expected, actual)
+		}
+	}
+}
+
+func TestDecode_stat(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("file").AsFile(), "")
+		expect(temp.Join("link").AsSymlink().LinkTo("file"))
+		opts := pathlib.DecodeOptions{Base: temp, Stat: true}
+
+		expect(pathlib.Decode[pathlib.File]("file", opts))
+		expect(pathlib.Decode[pathlib.File]("link", opts))
+		expect(pathlib.Decode[pathlib.Symlink]("link", opts))
+		expect(pathlib.Decode[pathlib.Dir](".", opts))
+		expect(pathlib.Decode[pathlib.PathStr]("file", opts))
+
+		var wrongDir pathlib.WrongTypeOnDisk[pathlib.Dir]
+		if _, err := pathlib.Decode[pathlib.Dir]("file", opts); !errors.As(err, &wrongDir) {
+			t.Errorf("expected WrongTypeOnDisk, got %v", err)
+		}
+		var wrongLink pathlib.WrongTypeOnDisk[pathlib.Symlink]
+		if _, err := pathlib.Decode[pathlib.Symlink]("file", opts); !errors.As(err, &wrongLink) {
+			t.Errorf("expected WrongTypeOnDisk, got %v", err)
+		}
+		if _, err := pathlib.Decode[pathlib.PathStr]("missing", opts); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestDecodeOptions_Apply(t *testing.T) {
+	type inner struct {
+		Files  []pathlib.File
+		hidden pathlib.Dir
+	}
+	type config struct {
+		Root   pathlib.Dir
+		Named  map[string]pathlib.PathStr
+		Inner  inner
+		Ptr    *inner
+		Nil    *pathlib.Dir
+		Array  [1]pathlib.Symlink
+		Other  string
+		Nested map[string][]pathlib.Dir
+	}
+	c := config{
+		Root:   "root",
+		Named:  map[string]pathlib.PathStr{"x": "x", "abs": "/abs"},
+		Inner:  inner{Files: []pathlib.File{"a", "b"}, hidden: "hidden"},
+		Ptr:    &inner{Files: []pathlib.File{"c"}},
+		Array:  [1]pathlib.Symlink{"link"},
+		Other:  "other",
+		Nested: map[string][]pathlib.Dir{"n": {"n"}},
+	}
+	enforce(pathlib.DecodeOptions{Base: "/base"}.Apply(&c))
+	expected := config{
+		Root:   "/base/root",
+		Named:  map[string]pathlib.PathStr{"x": "/base/x", "abs": "/abs"},
+		Inner:  inner{Files: []pathlib.File{"/base/a", "/base/b"}, hidden: "hidden"},
+		Ptr:    &inner{Files: []pathlib.File{"/base/c"}},
+		Array:  [1]pathlib.Symlink{"/base/link"},
+		Other:  "other",
+		Nested: map[string][]pathlib.Dir{"n": {"/base/n"}},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("expected %+v, got %+v", expected, c)
+	}
+
+	if err := (pathlib.DecodeOptions{}).Apply(c); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+	missing := struct{ F pathlib.File }{"missing"}
+	if err := (pathlib.DecodeOptions{Base: pathlib.Dir(t.TempDir()), Stat: true}).Apply(&missing); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+}
+
+func TestDecodeOptions_Apply_aliasing(t *testing.T) {
+	type pair struct{ First, Second pathlib.Dir }
+	type node struct {
+		Dir  pathlib.Dir
+		Next *node
+	}
+	p := &pair{First: "a", Second: "b"}
+	n := &node{Dir: "n"}
+	n.Next = n
+	c := struct {
+		First *pathlib.Dir // reached before the struct that contains it
+		Pair  *pair
+		Any   any
+		Nodes any
+	}{First: &p.First, Pair: p, Any: pathlib.File("c"), Nodes: n}
+	enforce(pathlib.DecodeOptions{Base: "/base"}.Apply(&c))
+	if *p != (pair{First: "/base/a", Second: "/base/b"}) {
+		t.Errorf("unexpected %+v", *p)
+	}
+	if c.Any != pathlib.File("/base/c") {
+		t.Errorf("unexpected %#v", c.Any)
+	}
+	if n.Dir != "/base/n" {
+		t.Errorf("unexpected %q", n.Dir)
+	}
+}
+
+func TestDecodeOptions_Hook(t *testing.T) {
+	hook := pathlib.DecodeOptions{Base: "/base"}.Hook()
+	str := reflect.TypeFor[string]()
+	if actual := expect(hook(str, reflect.TypeFor[pathlib.Dir](), "d")); actual != pathlib.Dir("/base/d") {
+		t.Errorf("expected a Dir, got %#v", actual)
+	}
+	if actual := expect(hook(str, reflect.TypeFor[pathlib.File](), "f")); actual != pathlib.File("/base/f") {
+		t.Errorf("expected a File, got %#v", actual)
+	}
+	if actual := expect(hook(str, str, "s")); actual != "s" {
+		t.Errorf("expected other types to pass through, got %#v", actual)
+	}
+	if actual := expect(hook(reflect.TypeFor[int](), reflect.TypeFor[pathlib.Dir](), 1)); actual != 1 {
+		t.Errorf("expected non-strings to pass through, got %#v", actual)
+	}
+}
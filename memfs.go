@@ -0,0 +1,984 @@
+package pathlib
+
+import (
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// the maximum number of symlinks followed while resolving a path, matching Linux's MAXSYMLINKS.
+const maxSymlinks = 40
+
+// A fully in-memory [Filesystem] with directories, regular files and symlinks.
+// Paths use forward slashes internally. Relative names are resolved against the
+// working directory, since that is how [Bind] routes them to a MemFS.
+// Symlinks are resolved component-by-component, so ".." after a symlink refers to
+// the parent of the link's target, as it would on-disk.
+//
+// Use [Bind] to route paths to a MemFS:
+//
+//	mem := NewMemFS()
+//	_ = mem.MkdirAll("/virtual", 0o755)
+//	defer Bind("/virtual", mem)()
+//
+// MemFS is safe for concurrent use.
+type MemFS struct {
+	mu      sync.Mutex
+	root    *memNode
+	umask   fs.FileMode
+	dev     uint64
+	nextIno uint64
+}
+
+// each MemFS acts as a distinct device.
+var memDevices atomic.Uint64
+
+var _ Filesystem = (*MemFS)(nil)
+
+// Returns an empty [MemFS] containing only a root directory, with a umask of 022.
+func NewMemFS() *MemFS {
+	m := &MemFS{umask: 0o022, dev: memDevices.Add(1)}
+	m.root = m.newNode(fs.ModeDir | 0o755)
+	return m
+}
+
+// Sets the mask applied to the permissions of newly-created files and directories,
+// returning the previous mask. See [syscall.Umask].
+func (m *MemFS) SetUmask(mask fs.FileMode) (previous fs.FileMode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, m.umask = m.umask, mask&fs.ModePerm
+	return
+}
+
+// The value returned by [fs.FileInfo.Sys] for paths in a [MemFS].
+type MemStat struct {
+	Dev   uint64
+	Ino   uint64
+	Nlink uint64
+	Uid   int
+	Gid   int
+	Atime time.Time
+	Ctime time.Time
+}
+
+type memNode struct {
+	mode     fs.FileMode
+	uid, gid int
+	dev, ino uint64
+	links    uint64 // names referring to a non-directory
+	atime    time.Time
+	mtime    time.Time
+	ctime    time.Time
+	data     []byte              // regular files
+	target   string              // symlinks
+	children map[string]*memNode // directories
+	xattrs   map[string][]byte
+}
+
+// must be called with m.mu held.
+func (m *MemFS) newNode(mode fs.FileMode) *memNode {
+	now := time.Now()
+	m.nextIno++
+	n := &memNode{
+		mode:  mode,
+		uid:   os.Getuid(),
+		gid:   os.Getgid(),
+		dev:   m.dev,
+		ino:   m.nextIno,
+		links: 1,
+		atime: now,
+		mtime: now,
+		ctime: now,
+	}
+	if mode.IsDir() {
+		n.children = map[string]*memNode{}
+	}
+	return n
+}
+
+func (n *memNode) isSymlink() bool {
+	return n.mode&fs.ModeSymlink != 0
+}
+
+func (n *memNode) touch() {
+	n.mtime = time.Now()
+	n.ctime = n.mtime
+}
+
+func (n *memNode) info(name string) memInfo {
+	size := int64(len(n.data))
+	if n.isSymlink() {
+		size = int64(len(n.target))
+	}
+	return memInfo{
+		name:  name,
+		size:  size,
+		mode:  n.mode,
+		mtime: n.mtime,
+		sys:   MemStat{Dev: n.dev, Ino: n.ino, Nlink: n.nlink(), Uid: n.uid, Gid: n.gid, Atime: n.atime, Ctime: n.ctime},
+	}
+}
+
+// counts names that refer to the node: a directory is named by its parent, its own
+// "." and each subdirectory's "..".
+func (n *memNode) nlink() uint64 {
+	if !n.mode.IsDir() {
+		return n.links
+	}
+	links := uint64(2)
+	for _, child := range n.children {
+		if child.mode.IsDir() {
+			links++
+		}
+	}
+	return links
+}
+
+// A snapshot of a [memNode]'s metadata.
+type memInfo struct {
+	name  string
+	size  int64
+	mode  fs.FileMode
+	mtime time.Time
+	sys   MemStat
+}
+
+var _ fs.FileInfo = memInfo{}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) Mode() fs.FileMode  { return i.mode }
+func (i memInfo) ModTime() time.Time { return i.mtime }
+func (i memInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memInfo) Sys() any           { return &i.sys }
+
+// path resolution ---------------------------------------------------------------
+
+// The result of resolving a path: the directory containing the final component,
+// the final component's name, and the node it names (nil if it doesn't exist).
+type memLookup struct {
+	// directories traversed to reach the parent, starting at the root.
+	ancestors []*memNode
+	parent    *memNode
+	base      string
+	node      *memNode
+}
+
+func splitMemPath(name string) (parts []string, absolute bool) {
+	name = filepath.ToSlash(name)
+	name = name[len(filepath.VolumeName(name)):]
+	absolute = strings.HasPrefix(name, "/")
+	for _, part := range strings.Split(name, "/") {
+		if part != "" && part != "." {
+			parts = append(parts, part)
+		}
+	}
+	return
+}
+
+// splits a name passed to a MemFS method, resolving it against the working directory
+// if it's relative.
+func splitMemName(name string) []string {
+	parts, absolute := splitMemPath(name)
+	if !absolute {
+		if cwd, err := os.Getwd(); err == nil {
+			cwdParts, _ := splitMemPath(cwd)
+			parts = append(cwdParts, parts...)
+		}
+	}
+	return parts
+}
+
+// must be called with m.mu held.
+func (m *MemFS) lookup(name string, followLast bool) (result memLookup, err error) {
+	if name == "" {
+		return result, syscall.ENOENT
+	}
+	// a trailing slash means the final component must be a directory
+	trailingSlash := strings.HasSuffix(filepath.ToSlash(name), "/")
+	followLast = followLast || trailingSlash
+	pending := splitMemName(name)
+	stack := []*memNode{m.root}
+	hops := 0
+	for len(pending) > 0 {
+		part := pending[0]
+		pending = pending[1:]
+		cur := stack[len(stack)-1]
+		if !cur.mode.IsDir() {
+			return result, syscall.ENOTDIR
+		}
+		if part == ".." {
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			if len(pending) == 0 {
+				parent := stack[len(stack)-1]
+				return memLookup{ancestors: stack, parent: parent, base: ".", node: parent}, nil
+			}
+			continue
+		}
+		child := cur.children[part]
+		last := len(pending) == 0
+		if child != nil && child.isSymlink() && (!last || followLast) {
+			if hops++; hops > maxSymlinks {
+				return result, errLoop
+			}
+			target, absolute := splitMemPath(child.target)
+			if absolute {
+				stack = stack[:1]
+			}
+			pending = append(target, pending...)
+			if len(pending) == 0 { // the link points at "/" or "."
+				node := stack[len(stack)-1]
+				return memLookup{ancestors: stack, parent: node, base: ".", node: node}, nil
+			}
+			continue
+		}
+		if last {
+			if child != nil && trailingSlash && !child.mode.IsDir() {
+				return result, syscall.ENOTDIR
+			}
+			return memLookup{ancestors: stack, parent: cur, base: part, node: child}, nil
+		}
+		if child == nil {
+			return result, syscall.ENOENT
+		}
+		stack = append(stack, child)
+	}
+	// the path refers to the root
+	return memLookup{ancestors: stack, parent: m.root, base: "/", node: m.root}, nil
+}
+
+// like lookup, but the final component must exist.
+func (m *MemFS) find(name string, followLast bool) (*memNode, error) {
+	l, err := m.lookup(name, followLast)
+	if err == nil && l.node == nil {
+		err = syscall.ENOENT
+	}
+	return l.node, err
+}
+
+func memErr(op, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// Filesystem ------------------------------------------------------------------
+
+// Stat implements [Filesystem].
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	return m.stat("stat", name, true)
+}
+
+// Lstat implements [Filesystem].
+func (m *MemFS) Lstat(name string) (fs.FileInfo, error) {
+	return m.stat("lstat", name, false)
+}
+
+func (m *MemFS) stat(op, name string, follow bool) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return nil, memErr(op, name, err)
+	}
+	return node.info(filepath.Base(name)), nil
+}
+
+// OpenFile implements [Filesystem].
+func (m *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (RawFile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, err := m.lookup(name, flag&(os.O_CREATE|os.O_EXCL) != os.O_CREATE|os.O_EXCL)
+	if err != nil {
+		return nil, memErr("open", name, err)
+	}
+	node := l.node
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	switch {
+	case node == nil && flag&os.O_CREATE == 0:
+		return nil, memErr("open", name, syscall.ENOENT)
+	case node == nil:
+		node = m.newNode(perm.Perm() &^ m.umask)
+		l.parent.children[l.base] = node
+		l.parent.touch()
+	case flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL:
+		return nil, memErr("open", name, syscall.EEXIST)
+	case node.mode.IsDir() && writable:
+		return nil, memErr("open", name, syscall.EISDIR)
+	case flag&os.O_TRUNC != 0 && writable:
+		node.data = nil
+		node.touch()
+	}
+	return &memFile{fs: m, name: name, node: node, flag: flag}, nil
+}
+
+// ReadFile implements [Filesystem].
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, true)
+	if err != nil {
+		return nil, memErr("open", name, err)
+	}
+	if node.mode.IsDir() {
+		return nil, memErr("read", name, syscall.EISDIR)
+	}
+	node.atime = time.Now()
+	return slices.Clone(node.data), nil
+}
+
+// ReadDir implements [Filesystem]. Entries are sorted by name.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, true)
+	if err != nil {
+		return nil, memErr("open", name, err)
+	}
+	if !node.mode.IsDir() {
+		return nil, memErr("readdirent", name, syscall.ENOTDIR)
+	}
+	entries := make([]fs.DirEntry, 0, len(node.children))
+	for childName, child := range node.children {
+		entries = append(entries, fs.FileInfoToDirEntry(child.info(childName)))
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+// Mkdir implements [Filesystem].
+func (m *MemFS) Mkdir(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return memErr("mkdir", name, m.mkdir(name, perm))
+}
+
+// must be called with m.mu held.
+func (m *MemFS) mkdir(name string, perm fs.FileMode) error {
+	l, err := m.lookup(name, false)
+	if err != nil {
+		return err
+	}
+	if l.node != nil {
+		return syscall.EEXIST
+	}
+	l.parent.children[l.base] = m.newNode(fs.ModeDir | (perm.Perm() &^ m.umask))
+	l.parent.touch()
+	return nil
+}
+
+// MkdirAll implements [Filesystem].
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current := "/"
+	for _, part := range splitMemName(name) {
+		current = path.Join(current, part)
+		node, err := m.find(current, true)
+		switch {
+		case err == nil && node.mode.IsDir():
+			continue
+		case err == nil:
+			return memErr("mkdir", current, syscall.ENOTDIR)
+		}
+		if err = m.mkdir(current, perm); err != nil {
+			return memErr("mkdir", current, err)
+		}
+	}
+	return nil
+}
+
+// Remove implements [Filesystem].
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, err := m.lookup(name, false)
+	if err == nil && l.node == nil {
+		err = syscall.ENOENT
+	}
+	if err == nil && (l.node == m.root || l.base == ".") {
+		err = syscall.EBUSY
+	}
+	if err == nil && l.node.mode.IsDir() && len(l.node.children) > 0 {
+		err = errNotEmpty
+	}
+	if err != nil {
+		return memErr("remove", name, err)
+	}
+	delete(l.parent.children, l.base)
+	l.parent.touch()
+	l.node.unlink()
+	return nil
+}
+
+// drops a name referring to the node. Removing a directory drops its children's names.
+func (n *memNode) unlink() {
+	if n.mode.IsDir() {
+		for _, child := range n.children {
+			child.unlink()
+		}
+		return
+	}
+	n.links--
+	n.ctime = time.Now()
+}
+
+// RemoveAll implements [Filesystem]. Like [os.RemoveAll], it returns nil if the path doesn't exist.
+func (m *MemFS) RemoveAll(name string) error {
+	if name == "" {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, err := m.lookup(name, false)
+	if err == syscall.ENOENT || (err == nil && l.node == nil) {
+		return nil
+	}
+	if err == nil && (l.base == "." || l.base == "..") {
+		err = syscall.EINVAL
+	}
+	if err != nil {
+		return memErr("unlinkat", name, err)
+	}
+	if l.node == m.root {
+		for _, child := range m.root.children {
+			child.unlink()
+		}
+		clear(m.root.children)
+	} else {
+		delete(l.parent.children, l.base)
+		l.node.unlink()
+	}
+	l.parent.touch()
+	return nil
+}
+
+// Rename implements [Filesystem].
+func (m *MemFS) Rename(oldName, newName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	linkErr := func(err error) error {
+		return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: err}
+	}
+	src, err := m.lookup(oldName, false)
+	if err == nil && src.node == nil {
+		err = syscall.ENOENT
+	}
+	if err != nil {
+		return linkErr(err)
+	}
+	dst, err := m.lookup(newName, false)
+	if err != nil {
+		return linkErr(err)
+	}
+	if src.node == dst.node {
+		return nil
+	}
+	if src.base == "." || dst.base == "." || dst.base == "/" {
+		return linkErr(syscall.EBUSY)
+	}
+	if src.node == m.root || slices.Contains(dst.ancestors, src.node) || dst.parent == src.node {
+		// can't move a directory into itself
+		return linkErr(syscall.EINVAL)
+	}
+	if dst.node != nil {
+		switch {
+		case src.node.mode.IsDir() && !dst.node.mode.IsDir():
+			return linkErr(syscall.ENOTDIR)
+		case !src.node.mode.IsDir() && dst.node.mode.IsDir():
+			return linkErr(syscall.EISDIR)
+		case dst.node.mode.IsDir() && len(dst.node.children) > 0:
+			return linkErr(errNotEmpty)
+		}
+	}
+	if dst.node != nil {
+		dst.node.unlink()
+	}
+	delete(src.parent.children, src.base)
+	dst.parent.children[dst.base] = src.node
+	src.parent.touch()
+	dst.parent.touch()
+	src.node.ctime = time.Now()
+	return nil
+}
+
+// Chmod implements [Filesystem].
+func (m *MemFS) Chmod(name string, mode fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, true)
+	if err != nil {
+		return memErr("chmod", name, err)
+	}
+	node.chmod(mode)
+	return nil
+}
+
+func (n *memNode) chmod(mode fs.FileMode) {
+	n.mode = n.mode&^chmodMask | mode&chmodMask
+	n.ctime = time.Now()
+}
+
+// Chown implements [Filesystem]. Like [os.Chown], a uid or gid of -1 leaves that value unchanged.
+func (m *MemFS) Chown(name string, uid, gid int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, true)
+	if err != nil {
+		return memErr("chown", name, err)
+	}
+	node.chown(uid, gid)
+	return nil
+}
+
+// Lchown implements [Filesystem].
+func (m *MemFS) Lchown(name string, uid, gid int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, false)
+	if err != nil {
+		return memErr("lchown", name, err)
+	}
+	node.chown(uid, gid)
+	return nil
+}
+
+func (n *memNode) chown(uid, gid int) {
+	if uid != -1 {
+		n.uid = uid
+	}
+	if gid != -1 {
+		n.gid = gid
+	}
+	n.ctime = time.Now()
+}
+
+// Chtimes implements [Filesystem]. Like [os.Chtimes], a zero [time.Time] leaves that
+// timestamp unchanged.
+func (m *MemFS) Chtimes(name string, atime, mtime time.Time) error {
+	return m.utimes("chtimes", name, atime, mtime, true)
+}
+
+var _ UtimesFilesystem = (*MemFS)(nil)
+
+// Utimes implements [UtimesFilesystem].
+func (m *MemFS) Utimes(name string, atime, mtime time.Time, follow bool) error {
+	op := "utimensat"
+	if !follow {
+		op = "lutimes"
+	}
+	return m.utimes(op, name, atime, mtime, follow)
+}
+
+func (m *MemFS) utimes(op, name string, atime, mtime time.Time, follow bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return memErr(op, name, err)
+	}
+	if !atime.IsZero() {
+		node.atime = resolveUtime(atime)
+	}
+	if !mtime.IsZero() {
+		node.mtime = resolveUtime(mtime)
+	}
+	node.ctime = time.Now()
+	return nil
+}
+
+// Symlink implements [Filesystem].
+func (m *MemFS) Symlink(target, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, err := m.lookup(name, false)
+	if err == nil && l.node != nil {
+		err = syscall.EEXIST
+	}
+	if err != nil {
+		return &os.LinkError{Op: "symlink", Old: target, New: name, Err: err}
+	}
+	node := m.newNode(fs.ModeSymlink | fs.ModePerm)
+	node.target = target
+	l.parent.children[l.base] = node
+	l.parent.touch()
+	return nil
+}
+
+// Readlink implements [Filesystem].
+func (m *MemFS) Readlink(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, false)
+	if err == nil && !node.isSymlink() {
+		err = syscall.EINVAL
+	}
+	if err != nil {
+		return "", memErr("readlink", name, err)
+	}
+	return node.target, nil
+}
+
+var _ HardLinkFilesystem = (*MemFS)(nil)
+
+// Link implements [HardLinkFilesystem]. Like link(2) on Linux, it doesn't follow a
+// symlink at oldName.
+func (m *MemFS) Link(oldName, newName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	src, err := m.lookup(oldName, false)
+	if err == nil && src.node == nil {
+		err = syscall.ENOENT
+	}
+	if err == nil && src.node.mode.IsDir() {
+		err = syscall.EPERM
+	}
+	var dst memLookup
+	if err == nil {
+		dst, err = m.lookup(newName, false)
+	}
+	if err == nil && dst.node != nil {
+		err = syscall.EEXIST
+	}
+	if err != nil {
+		return &os.LinkError{Op: "link", Old: oldName, New: newName, Err: err}
+	}
+	dst.parent.children[dst.base] = src.node
+	dst.parent.touch()
+	src.node.links++
+	src.node.ctime = time.Now()
+	return nil
+}
+
+// extended attributes ---------------------------------------------------------
+
+var _ XattrFilesystem = (*MemFS)(nil)
+
+// Getxattr implements [XattrFilesystem].
+func (m *MemFS) Getxattr(name, attr string, follow bool) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return nil, memErr("getxattr", name, err)
+	}
+	value, ok := node.xattrs[attr]
+	if !ok {
+		return nil, memErr("getxattr", name, errNoXattr)
+	}
+	return slices.Clone(value), nil
+}
+
+// Setxattr implements [XattrFilesystem].
+func (m *MemFS) Setxattr(name, attr string, value []byte, follow bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return memErr("setxattr", name, err)
+	}
+	if node.xattrs == nil {
+		node.xattrs = map[string][]byte{}
+	}
+	node.xattrs[attr] = slices.Clone(value)
+	node.ctime = time.Now()
+	return nil
+}
+
+// Listxattr implements [XattrFilesystem].
+func (m *MemFS) Listxattr(name string, follow bool) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return nil, memErr("listxattr", name, err)
+	}
+	return slices.Sorted(maps.Keys(node.xattrs)), nil
+}
+
+// Removexattr implements [XattrFilesystem].
+func (m *MemFS) Removexattr(name, attr string, follow bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.find(name, follow)
+	if err != nil {
+		return memErr("removexattr", name, err)
+	}
+	if _, ok := node.xattrs[attr]; !ok {
+		return memErr("removexattr", name, errNoXattr)
+	}
+	delete(node.xattrs, attr)
+	node.ctime = time.Now()
+	return nil
+}
+
+// open files ------------------------------------------------------------------
+
+type memFile struct {
+	fs     *MemFS
+	name   string
+	node   *memNode
+	flag   int
+	offset int64
+	closed bool
+	// the names ReadDir has yet to return, once it has been called
+	unread []string
+	listed bool
+}
+
+var _ RawFile = (*memFile)(nil)
+
+// must be called with f.fs.mu held.
+func (f *memFile) check(op string) error {
+	if f.closed {
+		return memErr(op, f.name, os.ErrClosed)
+	}
+	return nil
+}
+
+func (f *memFile) Name() string { return f.name }
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("stat"); err != nil {
+		return nil, err
+	}
+	return f.node.info(filepath.Base(f.name)), nil
+}
+
+func (f *memFile) Sync() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	return f.check("sync")
+}
+
+func (f *memFile) Truncate(size int64) error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("truncate"); err != nil {
+		return err
+	}
+	if size < 0 || f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return memErr("truncate", f.name, syscall.EINVAL)
+	}
+	if size <= int64(len(f.node.data)) {
+		f.node.data = f.node.data[:size]
+	} else {
+		f.node.data = append(f.node.data, make([]byte, size-int64(len(f.node.data)))...)
+	}
+	f.node.touch()
+	return nil
+}
+
+func (f *memFile) Chmod(mode fs.FileMode) error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("chmod"); err != nil {
+		return err
+	}
+	f.node.chmod(mode)
+	return nil
+}
+
+func (f *memFile) Chown(uid, gid int) error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("chown"); err != nil {
+		return err
+	}
+	f.node.chown(uid, gid)
+	return nil
+}
+
+func (f *memFile) SyscallConn() (syscall.RawConn, error) {
+	return nil, memErr("syscallconn", f.name, errNotSupported)
+}
+
+func (f *memFile) SetDeadline(time.Time) error      { return os.ErrNoDeadline }
+func (f *memFile) SetReadDeadline(time.Time) error  { return os.ErrNoDeadline }
+func (f *memFile) SetWriteDeadline(time.Time) error { return os.ErrNoDeadline }
+
+// Fd returns ^uintptr(0), the value [os.File.Fd] returns for an invalid descriptor.
+func (f *memFile) Fd() uintptr { return ^uintptr(0) }
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("close"); err != nil {
+		return err
+	}
+	f.closed = true
+	return nil
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("seek"); err != nil {
+		return 0, err
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.node.data))
+	default:
+		return 0, memErr("seek", f.name, syscall.EINVAL)
+	}
+	if offset < 0 {
+		return 0, memErr("seek", f.name, syscall.EINVAL)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// must be called with f.fs.mu held.
+func (f *memFile) checkRead(op string) error {
+	if err := f.check(op); err != nil {
+		return err
+	}
+	if f.flag&os.O_WRONLY != 0 {
+		return memErr(op, f.name, errBadFD)
+	}
+	if f.node.mode.IsDir() {
+		return memErr(op, f.name, syscall.EISDIR)
+	}
+	return nil
+}
+
+func (f *memFile) Read(b []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.checkRead("read"); err != nil {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if f.offset >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.node.data[f.offset:])
+	f.offset += int64(n)
+	f.node.atime = time.Now()
+	return n, nil
+}
+
+// Lists a directory in batches, like [os.File.ReadDir]. The sorted names are
+// snapshotted by the first call; entries removed since then are skipped.
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.check("readdirent"); err != nil {
+		return nil, err
+	}
+	if !f.node.mode.IsDir() {
+		return nil, memErr("readdirent", f.name, syscall.ENOTDIR)
+	}
+	if !f.listed {
+		f.unread, f.listed = slices.Sorted(maps.Keys(f.node.children)), true
+	}
+	var entries []fs.DirEntry
+	for len(f.unread) > 0 && (n <= 0 || len(entries) < n) {
+		name := f.unread[0]
+		f.unread = f.unread[1:]
+		if child, ok := f.node.children[name]; ok {
+			entries = append(entries, fs.FileInfoToDirEntry(child.info(name)))
+		}
+	}
+	if n > 0 && len(entries) == 0 {
+		return nil, io.EOF
+	}
+	return entries, nil
+}
+
+// See [os.File.ReadAt].
+func (f *memFile) ReadAt(b []byte, off int64) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.checkRead("read"); err != nil {
+		return 0, err
+	}
+	if off < 0 {
+		return 0, memErr("readat", f.name, syscall.EINVAL)
+	}
+	if off >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.node.data[off:])
+	f.node.atime = time.Now()
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// must be called with f.fs.mu held.
+func (f *memFile) checkWrite(op string) error {
+	if err := f.check(op); err != nil {
+		return err
+	}
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return memErr(op, f.name, errBadFD)
+	}
+	return nil
+}
+
+// writes b at off, growing the file as needed. Must be called with f.fs.mu held.
+func (f *memFile) writeAt(b []byte, off int64) {
+	end := off + int64(len(b))
+	if end > int64(len(f.node.data)) {
+		f.node.data = append(f.node.data, make([]byte, end-int64(len(f.node.data)))...)
+	}
+	copy(f.node.data[off:], b)
+	f.node.touch()
+}
+
+func (f *memFile) Write(b []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.checkWrite("write"); err != nil {
+		return 0, err
+	}
+	if f.flag&os.O_APPEND != 0 {
+		f.offset = int64(len(f.node.data))
+	}
+	f.writeAt(b, f.offset)
+	f.offset += int64(len(b))
+	return len(b), nil
+}
+
+// See [os.File.WriteAt], which also refuses files opened with O_APPEND.
+func (f *memFile) WriteAt(b []byte, off int64) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if err := f.checkWrite("write"); err != nil {
+		return 0, err
+	}
+	if f.flag&os.O_APPEND != 0 || off < 0 {
+		return 0, memErr("writeat", f.name, syscall.EINVAL)
+	}
+	f.writeAt(b, off)
+	return len(b), nil
+}
+
+func (f *memFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
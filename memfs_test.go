@@ -0,0 +1,207 @@
+package pathlib_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// Returns an empty directory backed by a fresh [pathlib.MemFS]. The binding is removed
+// when the test ends.
+func memDir(t *testing.T) pathlib.Dir {
+	t.Helper()
+	mem := pathlib.NewMemFS()
+	dir := pathlib.Dir("/mem").Join(t.Name()).AsDir()
+	if err := mem.MkdirAll(dir.String(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pathlib.Bind(dir, mem))
+	return dir
+}
+
+// Runs the test against an on-disk temporary directory and an in-memory one.
+func eachBackend(t *testing.T, test func(t *testing.T, temp pathlib.Dir)) {
+	t.Helper()
+	t.Run("os", func(t *testing.T) { test(t, pathlib.Dir(t.TempDir())) })
+	t.Run("mem", func(t *testing.T) { test(t, memDir(t)) })
+}
+
+func TestMemFS_dir(t *testing.T) {
+	temp := memDir(t)
+	dir := expect(temp.Join("a/b/c").AsDir().MakeAll(0o755, 0o700))
+	if !dir.Exists() {
+		t.Fatal("MakeAll should create the directory")
+	}
+	if mode := expect(dir.Stat()).Mode(); mode != fs.ModeDir|0o755 {
+		t.Errorf("expected %s, got %s", fs.ModeDir|0o755, mode)
+	}
+	if mode := expect(dir.Parent().Stat()).Mode(); mode != fs.ModeDir|0o700 {
+		t.Errorf("expected %s, got %s", fs.ModeDir|0o700, mode)
+	}
+	if _, err := dir.Make(0o755); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected fs.ErrExist, got %v", err)
+	}
+
+	for _, name := range []string{"z", "x", "y"} {
+		enforce(expect(dir.Join(name).AsFile().Make(0o644)).Close())
+	}
+	var names []string
+	for _, entry := range expect(dir.Read()) {
+		names = append(names, entry.Name())
+	}
+	if !slices.Equal(names, []string{"x", "y", "z"}) {
+		t.Errorf("expected sorted entries, got %q", names)
+	}
+
+	if err := dir.Remove(); !errors.Is(err, errNotEmpty) {
+		t.Errorf("expected ENOTEMPTY, got %v", err)
+	}
+	if _, err := temp.Join("a").AsDir().Rename(dir.Join("d")); !errors.Is(err, syscall.EINVAL) {
+		t.Errorf("expected EINVAL moving a directory into itself, got %v", err)
+	}
+	expect(temp.Join("a").AsDir().RemoveAll())
+	if dir.Exists() {
+		t.Error("RemoveAll should remove nested directories")
+	}
+}
+
+func TestMemFS_file(t *testing.T) {
+	temp := memDir(t)
+	file := temp.Join("nested/file.txt").AsFile()
+	handle := expect(file.MakeAll(0o666, 0o755))
+	expect(handle.WriteString("hello, world"))
+	expect(handle.Seek(0, io.SeekStart))
+	if data := string(expect(io.ReadAll(handle))); data != "hello, world" {
+		t.Errorf("read back %q", data)
+	}
+	enforce(handle.Truncate(5))
+	enforce(handle.Close())
+	if _, err := handle.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed, got %v", err)
+	}
+	if data := string(expect(file.Read())); data != "hello" {
+		t.Errorf("expected truncated contents, got %q", data)
+	}
+	if mode := expect(file.Stat()).Mode(); mode != 0o644 {
+		t.Errorf("expected umask to apply, got %s", mode)
+	}
+
+	appender := expect(file.Open(os.O_WRONLY|os.O_APPEND, 0))
+	expect(appender.WriteString("!"))
+	enforce(appender.Close())
+	if data := string(expect(file.Read())); data != "hello!" {
+		t.Errorf("expected appended contents, got %q", data)
+	}
+
+	if _, err := file.Open(os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected fs.ErrExist, got %v", err)
+	}
+	if _, err := temp.Join("missing.txt").AsFile().Open(os.O_RDONLY, 0); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := pathlib.File(temp).Read(); !errors.Is(err, syscall.EISDIR) {
+		t.Errorf("expected EISDIR, got %v", err)
+	}
+}
+
+func TestMemFS_symlink(t *testing.T) {
+	temp := memDir(t)
+	target := expect(temp.Join("a/b").AsDir().MakeAll(0o755, 0o755))
+	enforce(expect(temp.Join("a/sibling.txt").AsFile().Make(0o644)).Close())
+	link := expect(temp.Join("link").AsSymlink().LinkTo(pathlib.PathStr(target)))
+
+	if expect(link.Read()) != pathlib.PathStr(target) {
+		t.Errorf("unexpected link target %q", expect(link.Read()))
+	}
+	if !expect(link.Stat()).IsDir() {
+		t.Error("Stat should follow the link")
+	}
+	if expect(link.Lstat()).Mode().Type() != fs.ModeSymlink {
+		t.Error("Lstat should not follow the link")
+	}
+	// ".." is resolved relative to the link's target, not lexically. Note that Join
+	// would clean the path lexically.
+	if !pathlib.File(link + "/../sibling.txt").Exists() {
+		t.Error("expected link/../sibling.txt to resolve to a/sibling.txt")
+	}
+
+	dangling := expect(temp.Join("dangling").AsSymlink().LinkTo("nowhere"))
+	if _, err := dangling.Stat(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if !dangling.Exists() {
+		t.Error("a dangling link should still exist")
+	}
+
+	loop := expect(temp.Join("loop").AsSymlink().LinkTo("loop"))
+	if _, err := loop.Stat(); !errors.Is(err, errLoop) {
+		t.Errorf("expected ELOOP, got %v", err)
+	}
+	if _, err := pathlib.Symlink(target).Read(); !errors.Is(err, syscall.EINVAL) {
+		t.Errorf("expected EINVAL reading a non-link, got %v", err)
+	}
+}
+
+func TestMemFS_metadata(t *testing.T) {
+	temp := memDir(t)
+	mem := pathlib.FilesystemOf(temp).(*pathlib.MemFS)
+	before := time.Now()
+	file := temp.Join("file.txt").AsFile()
+	enforce(expect(file.Make(0o666)).Close())
+
+	previous := mem.SetUmask(0o077)
+	if previous != 0o022 {
+		t.Errorf("expected default umask 022, got %03o", previous)
+	}
+	private := expect(temp.Join("private").AsDir().Make(0o777))
+	if mode := expect(private.Stat()).Mode(); mode != fs.ModeDir|0o700 {
+		t.Errorf("expected umask 077 to apply, got %s", mode)
+	}
+
+	enforce(file.Chmod(0o600 | fs.ModeSetuid))
+	enforce(file.Chown(1234, -1))
+	info := expect(file.Stat())
+	if info.Mode() != 0o600|fs.ModeSetuid {
+		t.Errorf("unexpected mode %s", info.Mode())
+	}
+	stat := info.Sys().(*pathlib.MemStat)
+	if stat.Uid != 1234 || stat.Gid != os.Getgid() {
+		t.Errorf("unexpected ownership %d:%d", stat.Uid, stat.Gid)
+	}
+	if info.ModTime().Before(before) || stat.Ctime.Before(info.ModTime()) {
+		t.Errorf("unexpected timestamps: mtime=%s ctime=%s", info.ModTime(), stat.Ctime)
+	}
+	if other := expect(private.Stat()).Sys().(*pathlib.MemStat); other.Ino == stat.Ino {
+		t.Error("distinct nodes should have distinct inode numbers")
+	}
+}
+
+func TestMemFS_relative(t *testing.T) {
+	dir := pathlib.Dir(t.TempDir())
+	mem := pathlib.NewMemFS()
+	enforce(mem.MkdirAll(dir.String(), 0o755))
+	defer pathlib.Bind(dir, mem)()
+	t.Chdir(string(dir))
+
+	expect(pathlib.Dir("sub").Make(0o755))
+	writeFile(t, pathlib.File("sub/file"), "content")
+	if !dir.Join("sub").AsDir().Exists() || !pathlib.Dir("sub").Exists() {
+		t.Error("expected relative and absolute paths to agree")
+	}
+	if content := expect(dir.Join("sub", "file").AsFile().ReadString()); content != "content" {
+		t.Errorf("unexpected content %q", content)
+	}
+	if _, err := mem.Stat("/sub"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected nothing at /sub, got %v", err)
+	}
+	if _, err := os.Stat(dir.Join("sub").String()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected nothing on-disk, got %v", err)
+	}
+}
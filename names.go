@@ -0,0 +1,150 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+)
+
+var (
+	// Returned when a path's final component can't be replaced, e.g. for "/" or ".",
+	// or when the replacement is empty or contains a separator.
+	ErrInvalidName = errors.New("pathlib: invalid name")
+	// Returned when a suffix is neither empty nor a "." followed by a name.
+	ErrInvalidSuffix = errors.New("pathlib: invalid suffix")
+	// Returned by RelativeTo when a path isn't beneath the given directory.
+	ErrNotRelative = errors.New("pathlib: path is not relative to the directory")
+)
+
+func stem[P Kind](p P) string {
+	return hostFlavor.stem(string(p))
+}
+
+func suffixes[P Kind](p P) []string {
+	return hostFlavor.suffixes(string(p))
+}
+
+func withName[P Kind](p P, name string) (P, error) {
+	result, err := hostFlavor.withName(string(p), name)
+	return P(result), err
+}
+
+func withStem[P Kind](p P, stem string) (P, error) {
+	result, err := hostFlavor.withStem(string(p), stem)
+	return P(result), err
+}
+
+func withSuffix[P Kind](p P, suffix string) (P, error) {
+	result, err := hostFlavor.withSuffix(string(p), suffix)
+	return P(result), err
+}
+
+func isRelativeTo[P Kind](p P, base Dir) bool {
+	_, ok := hostFlavor.trimBase(string(p), string(base))
+	return ok
+}
+
+func relativeTo[P Kind](p P, base Dir) (P, error) {
+	result, err := hostFlavor.relativeTo(string(p), string(base))
+	return P(result), err
+}
+
+// returns the final component, or "" if there isn't one, as in "/" or ".".
+func (fl *flavor) name(s string) string {
+	s = s[fl.volumeNameLen(s):]
+	for len(s) > 0 && fl.isSeparator(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	i := len(s) - 1
+	for i >= 0 && !fl.isSeparator(s[i]) {
+		i--
+	}
+	if s = s[i+1:]; s == "." || s == ".." {
+		return ""
+	}
+	return s
+}
+
+// returns the final suffix, which unlike [path/filepath.Ext] excludes a leading dot.
+func suffix(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if 0 < i && i < len(name)-1 {
+		return name[i:]
+	}
+	return ""
+}
+
+func (fl *flavor) stem(s string) string {
+	name := fl.name(s)
+	return name[:len(name)-len(suffix(name))]
+}
+
+func (fl *flavor) suffixes(s string) []string {
+	name := fl.name(s)
+	if strings.HasSuffix(name, ".") {
+		return nil
+	}
+	parts := strings.Split(strings.TrimLeft(name, "."), ".")[1:]
+	for i := range parts {
+		parts[i] = "." + parts[i]
+	}
+	return parts
+}
+
+func (fl *flavor) withName(s, name string) (string, error) {
+	old := fl.name(s)
+	if old == "" || name == "" || name == "." || name == ".." || fl.hasSeparator(name) || fl.volumeNameLen(name) > 0 {
+		return s, &fs.PathError{Op: "withname", Path: s, Err: ErrInvalidName}
+	}
+	vol := s[:fl.volumeNameLen(s)]
+	dir := s[len(vol):]
+	for len(dir) > 0 && fl.isSeparator(dir[len(dir)-1]) {
+		dir = dir[:len(dir)-1]
+	}
+	return vol + dir[:len(dir)-len(old)] + name, nil
+}
+
+func (fl *flavor) withStem(s, stem string) (string, error) {
+	return fl.withName(s, stem+suffix(fl.name(s)))
+}
+
+func (fl *flavor) withSuffix(s, suffix string) (string, error) {
+	if suffix != "" && (suffix[0] != '.' || suffix == "." || fl.hasSeparator(suffix)) {
+		return s, &fs.PathError{Op: "withsuffix", Path: s, Err: ErrInvalidSuffix}
+	}
+	return fl.withName(s, fl.stem(s)+suffix)
+}
+
+// strips base from the front of s, comparing cleaned strings.
+func (fl *flavor) trimBase(s, base string) (string, bool) {
+	s, base = fl.clean(s), fl.clean(base)
+	if fl.isAbs(s) != fl.isAbs(base) || !fl.sameWord(fl.volumeName(s), fl.volumeName(base)) {
+		return "", false
+	}
+	sep := string(fl.separator)
+	switch {
+	case fl.sameWord(s, base):
+		return ".", true
+	case base == ".":
+		if s == ".." || strings.HasPrefix(s, ".."+sep) {
+			return "", false
+		}
+		return s, true
+	}
+	prefix := base
+	if !strings.HasSuffix(prefix, sep) {
+		prefix += sep
+	}
+	if len(s) > len(prefix) && fl.sameWord(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return "", false
+}
+
+func (fl *flavor) relativeTo(s, base string) (string, error) {
+	rest, ok := fl.trimBase(s, base)
+	if !ok {
+		return s, &fs.PathError{Op: "relativeto", Path: s, Err: ErrNotRelative}
+	}
+	return rest, nil
+}
@@ -0,0 +1,199 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExamplePathStr_Suffixes() {
+	for _, p := range []pathlib.PathStr{"dist/archive.tar.gz", ".bashrc", "README", "trailing."} {
+		fmt.Printf("%q: stem %q, suffixes %q\n", p, p.Stem(), p.Suffixes())
+	}
+	// Output:
+	// "dist/archive.tar.gz": stem "archive.tar", suffixes [".tar" ".gz"]
+	// ".bashrc": stem ".bashrc", suffixes []
+	// "README": stem "README", suffixes []
+	// "trailing.": stem "trailing.", suffixes []
+}
+
+func ExampleFile_WithSuffix() {
+	config := pathlib.File("/etc/app/config.yaml")
+	fmt.Println(expect(config.WithSuffix(".bak")))
+	fmt.Println(expect(config.WithSuffix("")))
+	fmt.Println(expect(config.WithStem("defaults")))
+	fmt.Println(expect(config.WithName("app.toml")))
+	// Output:
+	// /etc/app/config.bak
+	// /etc/app/config
+	// /etc/app/defaults.yaml
+	// /etc/app/app.toml
+}
+
+func ExamplePathStr_RelativeTo() {
+	p := pathlib.PathStr("/srv/www/static/app.js")
+	fmt.Println(expect(p.RelativeTo("/srv/www")))
+	fmt.Println(p.IsRelativeTo("/srv/www"), p.IsRelativeTo("/srv/w"), p.IsRelativeTo("srv"))
+	// Output:
+	// static/app.js
+	// true false false
+}
+
+func TestPathStr_names(t *testing.T) {
+	cases := []struct {
+		path     pathlib.PathStr
+		stem     string
+		suffixes []string
+	}{
+		{"a/b.txt", "b", []string{".txt"}},
+		{"a/b.tar.gz", "b.tar", []string{".tar", ".gz"}},
+		{"a/b.tar.gz/", "b.tar", []string{".tar", ".gz"}},
+		{"..tar.gz", "..tar", []string{".gz"}},
+		{".bashrc", ".bashrc", nil},
+		{"b.", "b.", nil},
+		{"/", "", nil},
+		{".", "", nil},
+		{"a/..", "", nil},
+		{"", "", nil},
+	}
+	for _, c := range cases {
+		if stem := c.path.Stem(); stem != c.stem {
+			t.Errorf("%q.Stem(): expected %q, got %q", c.path, c.stem, stem)
+		}
+		if suffixes := c.path.Suffixes(); !slices.Equal(suffixes, c.suffixes) {
+			t.Errorf("%q.Suffixes(): expected %q, got %q", c.path, c.suffixes, suffixes)
+		}
+	}
+}
+
+func TestPathStr_WithName(t *testing.T) {
+	valid := []struct{ path, name, expected pathlib.PathStr }{
+		{"a/b.txt", "c.md", "a/c.md"},
+		{"b.txt", "c", "c"},
+		{"/b", "c", "/c"},
+		{"a/b/", "c", "a/c"},
+		{"a//b", "c", "a//c"},
+	}
+	for _, c := range valid {
+		if actual := expect(c.path.WithName(string(c.name))); actual != c.expected {
+			t.Errorf("%q.WithName(%q): expected %q, got %q", c.path, c.name, c.expected, actual)
+		}
+	}
+	invalid := []struct{ path, name pathlib.PathStr }{
+		{"/", "c"},
+		{".", "c"},
+		{"", "c"},
+		{"a/..", "c"},
+		{"a/b", ""},
+		{"a/b", "c/d"},
+		{"a/b", "."},
+		{"a/b", ".."},
+	}
+	for _, c := range invalid {
+		if _, err := c.path.WithName(string(c.name)); !errors.Is(err, pathlib.ErrInvalidName) {
+			t.Errorf("%q.WithName(%q): expected ErrInvalidName, got %v", c.path, c.name, err)
+		}
+	}
+}
+
+func TestPathStr_WithSuffix(t *testing.T) {
+	valid := []struct{ path, suffix, expected pathlib.PathStr }{
+		{"a/b.txt", ".md", "a/b.md"},
+		{"a/b.tar.gz", ".bz2", "a/b.tar.bz2"},
+		{"a/b", ".txt", "a/b.txt"},
+		{"a/.bashrc", ".bak", "a/.bashrc.bak"},
+		{"a/b.txt", "", "a/b"},
+		{"a/b.txt", ".tar.gz", "a/b.tar.gz"},
+	}
+	for _, c := range valid {
+		if actual := expect(c.path.WithSuffix(string(c.suffix))); actual != c.expected {
+			t.Errorf("%q.WithSuffix(%q): expected %q, got %q", c.path, c.suffix, c.expected, actual)
+		}
+	}
+	for _, suffix := range []string{"txt", ".", "./x"} {
+		if _, err := pathlib.PathStr("a/b").WithSuffix(suffix); !errors.Is(err, pathlib.ErrInvalidSuffix) {
+			t.Errorf("WithSuffix(%q): expected ErrInvalidSuffix, got %v", suffix, err)
+		}
+	}
+	if _, err := pathlib.PathStr("/").WithSuffix(".txt"); !errors.Is(err, pathlib.ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+	if actual := expect(pathlib.PathStr("a/b.txt").WithStem("c")); actual != "a/c.txt" {
+		t.Errorf("expected %q, got %q", "a/c.txt", actual)
+	}
+}
+
+func TestPathStr_RelativeTo(t *testing.T) {
+	valid := []struct {
+		path     pathlib.PathStr
+		base     pathlib.Dir
+		expected pathlib.PathStr
+	}{
+		{"/a/b/c", "/a", "b/c"},
+		{"/a/b/c", "/a/b/", "c"},
+		{"/a/b", "/", "a/b"},
+		{"/a/b", "/a/b", "."},
+		{"a/b", ".", "a/b"},
+		{"a/b", "a", "b"},
+		{"./a/b", "a/", "b"},
+		{"../a/b", "..", "a/b"},
+	}
+	for _, c := range valid {
+		if !c.path.IsRelativeTo(c.base) {
+			t.Errorf("expected %q to be relative to %q", c.path, c.base)
+		}
+		if actual := expect(c.path.RelativeTo(c.base)); actual != c.expected {
+			t.Errorf("%q.RelativeTo(%q): expected %q, got %q", c.path, c.base, c.expected, actual)
+		}
+	}
+	invalid := []struct {
+		path pathlib.PathStr
+		base pathlib.Dir
+	}{
+		{"/a/bc", "/a/b"},
+		{"/a", "/a/b"},
+		{"a/b", "/a"},
+		{"/a/b", "a"},
+		{"../a", "."},
+		{"..", "."},
+	}
+	for _, c := range invalid {
+		if c.path.IsRelativeTo(c.base) {
+			t.Errorf("expected %q not to be relative to %q", c.path, c.base)
+		}
+		if _, err := c.path.RelativeTo(c.base); !errors.Is(err, pathlib.ErrNotRelative) {
+			t.Errorf("%q.RelativeTo(%q): expected ErrNotRelative, got %v", c.path, c.base, err)
+		}
+	}
+}
+
+func TestNames_typed(t *testing.T) {
+	var dir pathlib.Dir = expect(pathlib.Dir("/a/b.d").WithSuffix(".old"))
+	if dir != "/a/b.old" {
+		t.Errorf("unexpected %q", dir)
+	}
+	var link pathlib.Symlink = expect(pathlib.Symlink("/a/current").RelativeTo("/a"))
+	if link != "current" {
+		t.Errorf("unexpected %q", link)
+	}
+
+	temp := pathlib.Dir(t.TempDir())
+	file := expect(temp.Join("data.json").AsFile().WriteString("{}", 0o644))
+	info := expect(file.Stat())
+	var renamed pathlib.File = expect(info.(pathlib.PureTransformer[pathlib.File]).WithSuffix(".bak"))
+	if renamed != temp.Join("data.bak").AsFile() {
+		t.Errorf("unexpected %q", renamed)
+	}
+	h := expect(file.Open(os.O_RDONLY, 0))
+	defer func() { enforce(h.Close()) }()
+	if stem := h.(pathlib.Stemmer).Stem(); stem != "data" {
+		t.Errorf("unexpected stem %q", stem)
+	}
+	if rest := expect(h.(pathlib.PureTransformer[pathlib.File]).RelativeTo(temp)); rest != "data.json" {
+		t.Errorf("unexpected %q", rest)
+	}
+}
@@ -2,6 +2,7 @@ package pathlib
 
 import (
 	"io/fs"
+	"time"
 )
 
 type onDisk[P Kind] struct {
@@ -19,8 +20,14 @@ func (p onDisk[P]) Path() P {
 
 var _ fs.FileInfo = onDisk[PathStr]{}
 
+// returns the backend's own info, which [os.SameFile] requires.
+func (p onDisk[P]) unwrap() fs.FileInfo {
+	return p.FileInfo
+}
+
 // PurePath --------------------------------------------------------------------
 var _ PurePath = onDisk[PathStr]{}
+var _ Stemmer = onDisk[PathStr]{}
 
 // Parent implements [PurePath]
 func (p onDisk[P]) Parent() Dir {
@@ -57,8 +64,24 @@ func (p onDisk[P]) Parts() []string {
 	return p.Path().Parts()
 }
 
+// Stem implements [Stemmer].
+func (p onDisk[P]) Stem() string {
+	return stem(p.Path())
+}
+
+// Suffixes implements [Stemmer].
+func (p onDisk[P]) Suffixes() []string {
+	return suffixes(p.Path())
+}
+
+// IsRelativeTo implements [PureTransformer].
+func (p onDisk[P]) IsRelativeTo(base Dir) bool {
+	return isRelativeTo(p.Path(), base)
+}
+
 // Transformer -----------------------------------------------------------------
 var _ Transformer[PathStr] = onDisk[PathStr]{}
+var _ PureTransformer[PathStr] = onDisk[PathStr]{}
 
 // Convenience method to cast get the untyped string representation of the path.
 //
@@ -111,6 +134,34 @@ func (p onDisk[P]) ExpandUser() (P, error) {
 	return expandUser(p.Path())
 }
 
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (p onDisk[P]) WithName(name string) (P, error) {
+	return withName(p.Path(), name)
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (p onDisk[P]) WithStem(stem string) (P, error) {
+	return withStem(p.Path(), stem)
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (p onDisk[P]) WithSuffix(suffix string) (P, error) {
+	return withSuffix(p.Path(), suffix)
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (p onDisk[P]) RelativeTo(base Dir) (P, error) {
+	return relativeTo(p.Path(), base)
+}
+
 // Mover --------------------------------------------------------------------
 var _ Remover[PathStr] = onDisk[PathStr]{}
 
@@ -128,6 +179,7 @@ func (p onDisk[P]) Rename(destination PathStr) (P, error) {
 
 // Changer ----------------------------------------------------------------------
 var _ Changer = onDisk[PathStr]{}
+var _ TimeChanger = onDisk[PathStr]{}
 
 // See [os.Chmod].
 //
@@ -142,3 +194,11 @@ func (p onDisk[P]) Chmod(mode fs.FileMode) error {
 func (p onDisk[P]) Chown(uid, gid int) error {
 	return chown(p.Path(), uid, gid)
 }
+
+// Follows symlinks, unless the path is a [Symlink]. See [Symlink.Chtimes].
+//
+// Chtimes implements [TimeChanger].
+func (p onDisk[P]) Chtimes(atime, mtime time.Time) error {
+	_, isLink := any(p.p).(Symlink)
+	return chtimes(p.Path(), atime, mtime, !isLink)
+}
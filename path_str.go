@@ -5,6 +5,7 @@ import (
 	"iter"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 type PathStr string
@@ -37,6 +38,7 @@ func (p PathStr) Exists() bool {
 
 // PurePath --------------------------------------------------------------------
 var _ PurePath = PathStr(".")
+var _ Stemmer = PathStr(".")
 
 // A wrapper around [path/filepath.Join].
 func (p PathStr) Join(segments ...string) PathStr {
@@ -103,6 +105,31 @@ func (p PathStr) Ext() string {
 	return ext(p)
 }
 
+// Returns the final component without its final suffix. See [PathStr.Suffixes].
+//
+// Stem implements [Stemmer].
+func (p PathStr) Stem() string {
+	return stem(p)
+}
+
+// Returns the final component's suffixes in order, e.g. [".tar", ".gz"] for
+// "archive.tar.gz". Unlike [PathStr.Ext], a leading dot doesn't start a suffix, so
+// ".bashrc" has none. Neither does a name ending in a dot.
+//
+// Suffixes implements [Stemmer].
+func (p PathStr) Suffixes() []string {
+	return suffixes(p)
+}
+
+// Returns true if the path is the base directory or beneath it. Like [PathStr.RelativeTo],
+// this only compares the cleaned strings, so symlinks and the working directory are
+// ignored.
+//
+// IsRelativeTo implements [PureTransformer].
+func (p PathStr) IsRelativeTo(base Dir) bool {
+	return isRelativeTo(p, base)
+}
+
 // Returns true if the path is absolute, false otherwise.
 // See [path/filepath.IsAbs] for more details.
 //
@@ -149,6 +176,7 @@ func (p PathStr) Read() (any, error) {
 
 // Transformer -----------------------------------------------------------------
 var _ Transformer[PathStr] = PathStr(".")
+var _ PureTransformer[PathStr] = PathStr(".")
 
 // Convenience method to cast get the untyped string representation of the path.
 //
@@ -191,6 +219,41 @@ func (p PathStr) Rel(base Dir) (PathStr, error) {
 	return rel(base, p)
 }
 
+// Returns the path with its final component replaced by name. Fails with
+// [ErrInvalidName] if the path has no final component, as with "/" or ".", or if name
+// is empty or contains a separator.
+//
+// WithName implements [PureTransformer].
+func (p PathStr) WithName(name string) (PathStr, error) {
+	return withName(p, name)
+}
+
+// Returns the path with the final component's stem replaced, keeping its final suffix.
+// See [PathStr.WithName].
+//
+// WithStem implements [PureTransformer].
+func (p PathStr) WithStem(stem string) (PathStr, error) {
+	return withStem(p, stem)
+}
+
+// Returns the path with the final suffix replaced by suffix, which must be empty or
+// start with a dot. An empty suffix removes the final suffix; if there isn't one,
+// suffix is appended. Fails with [ErrInvalidSuffix] for other suffixes.
+//
+// WithSuffix implements [PureTransformer].
+func (p PathStr) WithSuffix(suffix string) (PathStr, error) {
+	return withSuffix(p, suffix)
+}
+
+// Returns the rest of the path after base, or [ErrNotRelative] if the path isn't
+// beneath base. Unlike [PathStr.Rel], this never adds ".." or consults the working
+// directory, so a relative path is never relative to an absolute one.
+//
+// RelativeTo implements [PureTransformer].
+func (p PathStr) RelativeTo(base Dir) (PathStr, error) {
+	return relativeTo(p, base)
+}
+
 // Expand a leading "~" into the user's home directory. If the home directory cannot be
 // determined, the path is returned unchanged.
 func (p PathStr) ExpandUser() (PathStr, error) {
@@ -199,6 +262,7 @@ func (p PathStr) ExpandUser() (PathStr, error) {
 
 // Changer ----------------------------------------------------------------------
 var _ Changer = PathStr(".")
+var _ TimeChanger = PathStr(".")
 
 // See [os.Chmod].
 //
@@ -214,6 +278,13 @@ func (p PathStr) Chown(uid int, gid int) error {
 	return chown(p, uid, gid)
 }
 
+// Follows symlinks.
+//
+// Chtimes implements [TimeChanger].
+func (p PathStr) Chtimes(atime, mtime time.Time) error {
+	return chtimes(p, atime, mtime, true)
+}
+
 // Mover ------------------------------------------------------------------------
 var _ Remover[PathStr] = PathStr(".")
 
@@ -270,3 +341,17 @@ func (p PathStr) AsFile() File {
 func (p PathStr) AsSymlink() Symlink {
 	return Symlink(p)
 }
+
+// conversions -----------------------------------------------------------------
+
+// Returns the path with forward slashes, like [path/filepath.ToSlash]. On Windows, the
+// volume name is kept, e.g. "C:/Users".
+func (p PathStr) AsPosix() PosixPath {
+	return PosixPath(hostFlavor.convert(string(p), posixFlavor))
+}
+
+// Returns the path with backslashes. On Unix, backslashes within names become
+// separators, so the conversion can't always be undone.
+func (p PathStr) AsWindows() WindowsPath {
+	return WindowsPath(hostFlavor.convert(string(p), windowsFlavor))
+}
@@ -0,0 +1,27 @@
+// Helpers for using pathlib in tests, kept apart so that importing pathlib doesn't
+// link package testing.
+package pathlibtest
+
+import (
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// Create a temporary directory inside d for the duration of a test or benchmark.
+// Unlike [testing.T.TempDir], the parent directory is up to the caller. Fails the
+// test if the directory can't be created; it is removed during cleanup. See
+// [pathlib.Dir.MkdirTemp] for how pattern is used.
+func MkdirTemp(tb testing.TB, d pathlib.Dir, pattern string) pathlib.Dir {
+	tb.Helper()
+	temp, err := d.MkdirTemp(pattern)
+	if err != nil {
+		tb.Fatalf("MkdirTemp: %v", err)
+	}
+	tb.Cleanup(func() {
+		if _, err := temp.RemoveAll(); err != nil {
+			tb.Errorf("removing temporary directory: %v", err)
+		}
+	})
+	return temp
+}
@@ -0,0 +1,22 @@
+package pathlibtest_test
+
+import (
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+	"github.com/skalt/pathlib.go/pathlibtest"
+)
+
+func TestMkdirTemp(t *testing.T) {
+	parent := pathlib.Dir(t.TempDir())
+	var temp pathlib.Dir
+	t.Run("sub", func(t *testing.T) {
+		temp = pathlibtest.MkdirTemp(t, parent, "tb-*")
+		if _, err := temp.Join("file.txt").AsFile().WriteString("", 0o644); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if temp.Parent() != parent || temp.Exists() {
+		t.Errorf("expected %q to be removed when the test ended", temp)
+	}
+}
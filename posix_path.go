@@ -0,0 +1,152 @@
+package pathlib
+
+// A path using POSIX syntax on any OS: "/" is the only separator and there are no volume names.
+//
+// Methods required by [PurePath] and [PureTransformer] return [PathStr] and take [Dir]
+// values, which hold POSIX syntax unchanged; convert them back with a cast, e.g.
+// PosixPath(p.Join("x")).
+type PosixPath string
+
+var (
+	_ PurePath                   = PosixPath("")
+	_ Stemmer                    = PosixPath("")
+	_ PureTransformer[PosixPath] = PosixPath("")
+)
+
+// PurePath --------------------------------------------------------------------
+
+// Join implements [PurePath].
+func (p PosixPath) Join(segments ...string) PathStr {
+	return PathStr(posixFlavor.join(append([]string{string(p)}, segments...)...))
+}
+
+// Parent implements [PurePath].
+func (p PosixPath) Parent() Dir {
+	return Dir(posixFlavor.dir(posixFlavor.clean(string(p))))
+}
+
+// BaseName implements [PurePath].
+func (p PosixPath) BaseName() string {
+	return posixFlavor.base(string(p))
+}
+
+// Ext implements [PurePath].
+func (p PosixPath) Ext() string {
+	return posixFlavor.ext(string(p))
+}
+
+// Parts implements [PurePath].
+func (p PosixPath) Parts() []string {
+	return posixFlavor.parts(string(p))
+}
+
+// IsAbsolute implements [PurePath].
+func (p PosixPath) IsAbsolute() bool {
+	return posixFlavor.isAbs(string(p))
+}
+
+// IsLocal implements [PurePath].
+func (p PosixPath) IsLocal() bool {
+	return posixFlavor.isLocal(string(p))
+}
+
+// See [PathStr.Stem].
+//
+// Stem implements [Stemmer].
+func (p PosixPath) Stem() string {
+	return posixFlavor.stem(string(p))
+}
+
+// See [PathStr.Suffixes].
+//
+// Suffixes implements [Stemmer].
+func (p PosixPath) Suffixes() []string {
+	return posixFlavor.suffixes(string(p))
+}
+
+// See [PathStr.IsRelativeTo].
+//
+// IsRelativeTo implements [PureTransformer].
+func (p PosixPath) IsRelativeTo(base Dir) bool {
+	_, ok := posixFlavor.trimBase(string(p), string(base))
+	return ok
+}
+
+// PureTransformer -------------------------------------------------------------
+
+// String implements [PureTransformer].
+func (p PosixPath) String() string {
+	return string(p)
+}
+
+// Clean implements [PureTransformer].
+func (p PosixPath) Clean() PosixPath {
+	return PosixPath(posixFlavor.clean(string(p)))
+}
+
+// Returns true if the cleaned paths are equal. Unlike [PathStr.Eq], relative
+// paths are never made absolute.
+//
+// Eq implements [PureTransformer].
+func (p PosixPath) Eq(other PosixPath) bool {
+	return posixFlavor.sameWord(posixFlavor.clean(string(p)), posixFlavor.clean(string(other)))
+}
+
+// Rel implements [PureTransformer].
+func (p PosixPath) Rel(base Dir) (PosixPath, error) {
+	result, err := posixFlavor.rel(string(base), string(p))
+	return PosixPath(result), err
+}
+
+// Converts a slash-separated [io/fs] path, rejecting NUL bytes.
+//
+// Localize implements [PureTransformer].
+func (p PosixPath) Localize() (PosixPath, error) {
+	result, err := posixFlavor.localize(string(p))
+	return PosixPath(result), err
+}
+
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (p PosixPath) WithName(name string) (PosixPath, error) {
+	result, err := posixFlavor.withName(string(p), name)
+	return PosixPath(result), err
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (p PosixPath) WithStem(stem string) (PosixPath, error) {
+	result, err := posixFlavor.withStem(string(p), stem)
+	return PosixPath(result), err
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (p PosixPath) WithSuffix(suffix string) (PosixPath, error) {
+	result, err := posixFlavor.withSuffix(string(p), suffix)
+	return PosixPath(result), err
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (p PosixPath) RelativeTo(base Dir) (PosixPath, error) {
+	result, err := posixFlavor.relativeTo(string(p), string(base))
+	return PosixPath(result), err
+}
+
+// conversions -----------------------------------------------------------------
+
+// Returns the path with backslashes. Backslashes within names become separators, so
+// the conversion can't always be undone.
+func (p PosixPath) AsWindows() WindowsPath {
+	return WindowsPath(posixFlavor.convert(string(p), windowsFlavor))
+}
+
+// Returns the path with the current OS's separators. See [PathStr.AsPosix].
+func (p PosixPath) AsPathStr() PathStr {
+	return PathStr(posixFlavor.convert(string(p), hostFlavor))
+}
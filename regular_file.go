@@ -1,8 +1,11 @@
 package pathlib
 
 import (
+	"errors"
+	"io"
 	"io/fs"
 	"os"
+	"time"
 )
 
 // A path that represents a file.
@@ -10,15 +13,16 @@ type File PathStr
 
 // See [os.OpenFile].
 func (f File) Open(flag int, perm fs.FileMode) (FileHandle, error) {
-	ptr, err := os.OpenFile(string(f), flag, perm)
+	h, err := openFile(f, flag, perm)
 	if err != nil {
 		return nil, err
 	}
-	return &handle{ptr}, nil
+	return h, nil
 }
 
 // PurePath --------------------------------------------------------------------
 var _ PurePath = File("./example.txt")
+var _ Stemmer = File("./example.txt")
 
 // BaseName implements [PurePath].
 func (f File) BaseName() string {
@@ -55,8 +59,24 @@ func (f File) Parts() []string {
 	return PathStr(f).Parts()
 }
 
+// Stem implements [Stemmer].
+func (f File) Stem() string {
+	return stem(f)
+}
+
+// Suffixes implements [Stemmer].
+func (f File) Suffixes() []string {
+	return suffixes(f)
+}
+
+// IsRelativeTo implements [PureTransformer].
+func (f File) IsRelativeTo(base Dir) bool {
+	return isRelativeTo(f, base)
+}
+
 // Transformer -----------------------------------------------------------------
 var _ Transformer[File] = File("./example")
+var _ PureTransformer[File] = File("./example")
 
 // Convenience method to cast get the untyped string representation of the path.
 //
@@ -110,6 +130,34 @@ func (f File) Rel(base Dir) (File, error) {
 	return rel(base, f)
 }
 
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (f File) WithName(name string) (File, error) {
+	return withName(f, name)
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (f File) WithStem(stem string) (File, error) {
+	return withStem(f, stem)
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (f File) WithSuffix(suffix string) (File, error) {
+	return withSuffix(f, suffix)
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (f File) RelativeTo(base Dir) (File, error) {
+	return relativeTo(f, base)
+}
+
 // Beholder --------------------------------------------------------------------
 var _ Beholder[File] = File("./example")
 
@@ -156,6 +204,7 @@ func (f File) Stat() (Info[File], error) {
 
 // Changer ----------------------------------------------------------------------
 var _ Changer = File("./example")
+var _ TimeChanger = File("./example")
 
 // See [os.Chmod].
 //
@@ -171,6 +220,13 @@ func (f File) Chown(uid int, gid int) error {
 	return chown(f, uid, gid)
 }
 
+// Follows symlinks.
+//
+// Chtimes implements [TimeChanger].
+func (f File) Chtimes(atime, mtime time.Time) error {
+	return chtimes(f, atime, mtime, true)
+}
+
 // Mover ------------------------------------------------------------------------
 var _ Remover[File] = File("./example")
 
@@ -213,6 +269,21 @@ func (f File) MakeAll(perm, parentPerm fs.FileMode) (result FileHandle, err erro
 	return f.Make(perm)
 }
 
+// Like touch(1): create an empty file if f doesn't exist, or else set its access and
+// modification times to the current time. New files get permissions 0o666, subject to
+// the umask. Returns f for chaining.
+func (f File) Touch() (File, error) {
+	err := f.Chtimes(UtimeNow, UtimeNow)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return f, err
+	}
+	h, err := openFile(f, os.O_WRONLY|os.O_CREATE, 0o666)
+	if err != nil {
+		return f, err
+	}
+	return f, h.Close()
+}
+
 // Readable --------------------------------------------------------------------
 var _ Readable[[]byte] = File("./example")
 
@@ -220,5 +291,51 @@ var _ Readable[[]byte] = File("./example")
 //
 // Read implements [Readable].
 func (f File) Read() ([]byte, error) {
-	return os.ReadFile(string(f))
+	return readFile(f)
+}
+
+// Read the whole file as a string.
+//
+// See [os.ReadFile].
+func (f File) ReadString() (string, error) {
+	data, err := f.Read()
+	return string(data), err
+}
+
+// Writing ---------------------------------------------------------------------
+
+// Replace the file's contents with data, creating the file with the given
+// permissions if it doesn't exist. Returns f for chaining.
+//
+// See [os.WriteFile].
+func (f File) Write(data []byte, perm fs.FileMode) (File, error) {
+	return f, writeFile(f, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, data, perm)
+}
+
+// Like [File.Write], but for strings.
+func (f File) WriteString(s string, perm fs.FileMode) (File, error) {
+	return f.Write([]byte(s), perm)
+}
+
+// Add data to the end of the file, creating the file with the given permissions
+// if it doesn't exist. Returns f for chaining.
+//
+// See [os.O_APPEND].
+func (f File) Append(data []byte, perm fs.FileMode) (File, error) {
+	return f, writeFile(f, os.O_WRONLY|os.O_CREATE|os.O_APPEND, data, perm)
+}
+
+func writeFile(f File, flag int, data []byte, perm fs.FileMode) error {
+	h, err := openFile(f, flag, perm)
+	if err != nil {
+		return err
+	}
+	n, err := h.Write(data)
+	if err == nil && n < len(data) {
+		err = io.ErrShortWrite
+	}
+	if closeErr := h.Close(); err == nil {
+		err = closeErr
+	}
+	return err
 }
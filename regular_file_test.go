@@ -1,8 +1,11 @@
 package pathlib_test
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"syscall"
 	"testing"
 
 	"github.com/skalt/pathlib.go"
@@ -174,3 +177,39 @@ func TestFile_makeAll_fail(t *testing.T) {
 	}
 
 }
+
+func ExampleFile_Write() {
+	file := pathlib.TempDir().Join("write-example.txt").AsFile()
+	defer func() { _ = file.Remove() }()
+
+	contents := expect(expect(expect(file.
+		WriteString("hello", 0o644)).
+		Append([]byte(", world"), 0o644)).
+		ReadString())
+	fmt.Println(contents)
+	// Output:
+	// hello, world
+}
+
+func TestFile_write(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file.txt").AsFile().Write([]byte("a long line"), 0o600))
+		if mode := expect(file.Stat()).Mode(); mode != 0o600 {
+			t.Errorf("expected mode %s, got %s", fs.FileMode(0o600), mode)
+		}
+		expect(file.WriteString("short", 0o644))
+		if data := expect(file.ReadString()); data != "short" {
+			t.Errorf("expected Write to truncate, got %q", data)
+		}
+		if mode := expect(file.Stat()).Mode(); mode != 0o600 {
+			t.Errorf("expected existing permissions to be kept, got %s", mode)
+		}
+		expect(file.Append([]byte("er"), 0o644))
+		if data := expect(file.ReadString()); data != "shorter" {
+			t.Errorf("expected appended contents, got %q", data)
+		}
+		if _, err := pathlib.File(temp).WriteString("", 0o644); !errors.Is(err, syscall.EISDIR) {
+			t.Errorf("expected EISDIR, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Returned when following symlinks leads back to a link that is already being followed.
+type SymlinkLoop struct {
+	// The links that form the cycle, starting and ending with the same link.
+	Chain []Symlink
+}
+
+func (s SymlinkLoop) Error() string {
+	hops := make([]string, len(s.Chain))
+	for i, link := range s.Chain {
+		hops[i] = string(link)
+	}
+	return "symlink loop: " + strings.Join(hops, " -> ")
+}
+
+// Unwrap returns [syscall.ELOOP], or its equivalent on Plan 9.
+func (s SymlinkLoop) Unwrap() error {
+	return errLoop
+}
+
+// Returned when a symlink's target doesn't exist.
+type DanglingSymlink struct {
+	Link   Symlink
+	Target PathStr
+}
+
+func (d DanglingSymlink) Error() string {
+	return fmt.Sprintf("dangling symlink: %s -> %s", d.Link, d.Target)
+}
+
+// Unwrap returns [fs.ErrNotExist].
+func (d DanglingSymlink) Unwrap() error {
+	return fs.ErrNotExist
+}
+
+// Returns the canonical absolute path, with every symlink resolved and every "." and
+// ".." removed, like Python's `Path.resolve(strict=...)`.
+//
+// If strict is false, missing path segments are appended without being resolved. If
+// strict is true, a missing segment is an error and a missing link target is a
+// [DanglingSymlink]. Either way, cycles are a [SymlinkLoop].
+//
+// Unlike [path/filepath.EvalSymlinks], this dispatches through the bound [Filesystem].
+func (p PathStr) Resolve(strict bool) (PathStr, error) {
+	start := string(p)
+	if !p.IsAbsolute() {
+		// not [PathStr.Abs], which would clean away ".." segments before links are resolved
+		cwd, err := Cwd()
+		if err != nil {
+			return p, err
+		}
+		start = string(cwd) + string(filepath.Separator) + start
+	}
+	r := resolver{strict: strict, seen: map[string]*string{}}
+	resolved, err := r.join("", start)
+	return PathStr(resolved), err
+}
+
+// Returns the canonical absolute path of the link's final target. See [PathStr.Resolve].
+func (s Symlink) Resolve(strict bool) (PathStr, error) {
+	return PathStr(s).Resolve(strict)
+}
+
+type resolver struct {
+	strict bool
+	// maps links to their resolved targets, or nil while a link is being resolved
+	seen map[string]*string
+	// the links currently being resolved, outermost first
+	stack []Symlink
+}
+
+// resolves rest relative to the already-resolved path.
+func (r *resolver) join(path, rest string) (string, error) {
+	if filepath.IsAbs(rest) {
+		path, rest = root(rest)
+	}
+	parts := PathStr(rest).Parts()
+	for i, name := range parts {
+		switch name {
+		case ".":
+			continue
+		case "..":
+			path = filepath.Dir(path)
+			continue
+		}
+		next := filepath.Join(path, name)
+		info, err := filesystemOf(next).Lstat(next)
+		if !r.strict && (errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR)) {
+			// nothing beyond a missing segment can be resolved
+			return filepath.Join(append([]string{next}, parts[i+1:]...)...), nil
+		} else if err != nil {
+			return next, err
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			path = next
+			continue
+		}
+		if resolved, ok := r.seen[next]; ok {
+			if resolved == nil {
+				return next, r.loop(Symlink(next))
+			}
+			path = *resolved
+			continue
+		}
+		target, err := readlink(Symlink(next))
+		if err != nil {
+			return next, err
+		}
+		r.seen[next] = nil
+		r.stack = append(r.stack, Symlink(next))
+		path, err = r.join(path, string(target))
+		r.stack = r.stack[:len(r.stack)-1]
+		if err != nil {
+			var dangling DanglingSymlink
+			if errors.Is(err, fs.ErrNotExist) && !errors.As(err, &dangling) {
+				err = DanglingSymlink{Link: Symlink(next), Target: target}
+			}
+			return path, err
+		}
+		resolved := path // path keeps changing as the loop continues
+		r.seen[next] = &resolved
+	}
+	return path, nil
+}
+
+// splits an absolute path into a canonical prefix and the rest.
+func root(p string) (prefix, rest string) {
+	if b := bindingOf(p); b != nil && hasPathPrefix(p, b.prefix) {
+		// paths are routed to backends lexically, so a binding's prefix is already canonical
+		return b.prefix, p[len(b.prefix):]
+	}
+	volume := filepath.VolumeName(p)
+	return volume + string(filepath.Separator), p[len(volume):]
+}
+
+func (r *resolver) loop(link Symlink) SymlinkLoop {
+	start := 0
+	for i, s := range r.stack {
+		if s == link {
+			start = i
+		}
+	}
+	chain := append([]Symlink{}, r.stack[start:]...)
+	return SymlinkLoop{Chain: append(chain, link)}
+}
+
+// Lazily yield each hop from s to its final target: first s, then each [Symlink] it
+// points to, then the final target as a [Dir], [File] or, for anything else, a
+// [PathStr]. Relative targets are joined to the link's parent directory.
+//
+// Only the last segment of each hop is followed; symlinked parent directories are
+// traversed but not yielded. Iteration stops with a [SymlinkLoop] or a
+// [DanglingSymlink] if the chain doesn't end.
+func (s Symlink) Chain() iter.Seq2[PurePath, error] {
+	return func(yield func(PurePath, error) bool) {
+		var visited []fs.FileInfo
+		var links []Symlink
+		var target PathStr // as written in the last link
+		current := PathStr(s)
+		for {
+			info, err := lstat(current)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) && len(links) > 0 {
+					err = DanglingSymlink{Link: links[len(links)-1], Target: target}
+				}
+				yield(nil, err)
+				return
+			}
+			switch mode := info.Mode(); {
+			case mode&fs.ModeSymlink != 0:
+				// followed below
+			case mode.IsDir():
+				yield(Dir(current), nil)
+				return
+			case mode.IsRegular():
+				yield(File(current), nil)
+				return
+			default:
+				yield(current, nil)
+				return
+			}
+
+			link := Symlink(current)
+			for i, seen := range visited {
+				if sameFile(seen, info) {
+					yield(nil, SymlinkLoop{Chain: append(links[i:len(links):len(links)], link)})
+					return
+				}
+			}
+			if !yield(link, nil) {
+				return
+			}
+			visited, links = append(visited, info), append(links, link)
+			if target, err = link.Read(); err != nil {
+				yield(nil, err)
+				return
+			}
+			current = target
+			if !target.IsAbsolute() {
+				current = link.Parent().Join(string(target))
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleSymlink_Chain() {
+	dir := expect(pathlib.TempDir().Join("chain-example").AsDir().Make(0o755))
+	defer func() { _, _ = dir.RemoveAll() }()
+	expect(dir.Join("config.toml").AsFile().WriteString("", 0o644))
+	expect(dir.Join("current").AsSymlink().LinkTo("config.toml"))
+	link := expect(dir.Join("link").AsSymlink().LinkTo("current"))
+
+	for hop, err := range link.Chain() {
+		enforce(err)
+		fmt.Printf("%T(%q)\n", hop, expect(pathlib.PathStr(hop.Join()).Rel(dir)))
+	}
+	// Output:
+	// pathlib.Symlink("link")
+	// pathlib.Symlink("current")
+	// pathlib.File("config.toml")
+}
+
+func TestPathStr_Resolve(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		temp = pathlib.Dir(expect(pathlib.PathStr(temp).Resolve(true)))
+		real := expect(temp.Join("real/nested").AsDir().MakeAll(0o755, 0o755))
+		expect(temp.Join("dirlink").AsSymlink().LinkTo("real/nested"))
+		expect(temp.Join("abs").AsSymlink().LinkTo(pathlib.PathStr(temp.Join("dirlink"))))
+
+		cases := map[pathlib.PathStr]pathlib.PathStr{
+			temp.Join("dirlink"):                 pathlib.PathStr(real),
+			temp.Join("abs"):                     pathlib.PathStr(real),
+			pathlib.PathStr(temp + "/abs/../x"):  temp.Join("real/x"),
+			pathlib.PathStr(temp + "/./real/.."): pathlib.PathStr(temp),
+		}
+		for input, expected := range cases {
+			if actual := expect(input.Resolve(false)); actual != expected {
+				t.Errorf("%q: expected %q, got %q", input, expected, actual)
+			}
+		}
+		if _, err := pathlib.PathStr(temp + "/abs/../x").Resolve(true); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist in strict mode, got %v", err)
+		}
+
+		// a link seen twice resolves to the same target both times
+		writeFile(t, real.Join("f").AsFile(), "")
+		expect(temp.Join("sibling").AsSymlink().LinkTo("real"))
+		revisit := pathlib.PathStr(temp + "/sibling/../sibling/nested/f")
+		for _, strict := range []bool{false, true} {
+			if actual := expect(revisit.Resolve(strict)); actual != real.Join("f") {
+				t.Errorf("%q (strict=%v): expected %q, got %q", revisit, strict, real.Join("f"), actual)
+			}
+		}
+	})
+}
+
+func TestSymlink_Resolve_errors(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		temp = pathlib.Dir(expect(pathlib.PathStr(temp).Resolve(true)))
+		dangling := expect(temp.Join("dangling").AsSymlink().LinkTo("missing/file"))
+		if actual := expect(dangling.Resolve(false)); actual != temp.Join("missing/file") {
+			t.Errorf("expected missing segments to be kept, got %q", actual)
+		}
+		_, err := dangling.Resolve(true)
+		var d pathlib.DanglingSymlink
+		if !errors.As(err, &d) || d.Link != dangling || d.Target != "missing/file" {
+			t.Errorf("expected a DanglingSymlink, got %#v", err)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Error("a DanglingSymlink should match fs.ErrNotExist")
+		}
+
+		a := expect(temp.Join("a").AsSymlink().LinkTo("b"))
+		b := expect(temp.Join("b").AsSymlink().LinkTo("a/child"))
+		for _, strict := range []bool{false, true} {
+			_, err = a.Resolve(strict)
+			var loop pathlib.SymlinkLoop
+			if !errors.As(err, &loop) || !slices.Equal(loop.Chain, []pathlib.Symlink{a, b, a}) {
+				t.Errorf("strict=%v: expected a SymlinkLoop through a and b, got %#v", strict, err)
+			}
+			if !errors.Is(err, errLoop) {
+				t.Error("a SymlinkLoop should match ELOOP")
+			}
+		}
+	})
+}
+
+// collects the hops of a chain, and the error that ended it.
+func hops(link pathlib.Symlink) (result []pathlib.PurePath, err error) {
+	for hop, hopErr := range link.Chain() {
+		if hopErr != nil {
+			return result, hopErr
+		}
+		result = append(result, hop)
+	}
+	return
+}
+
+func TestSymlink_Chain(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		first := expect(temp.Join("first").AsSymlink().LinkTo("second"))
+		second := expect(temp.Join("second").AsSymlink().LinkTo(pathlib.PathStr(dir)))
+		chain := expect(hops(first))
+		if expected := []pathlib.PurePath{first, second, dir}; !slices.Equal(chain, expected) {
+			t.Errorf("expected %#v, got %#v", expected, chain)
+		}
+
+		dangling := expect(temp.Join("dangling").AsSymlink().LinkTo("nowhere"))
+		chain, err := hops(dangling)
+		var d pathlib.DanglingSymlink
+		if !errors.As(err, &d) || d.Link != dangling || d.Target != "nowhere" || len(chain) != 1 {
+			t.Errorf("expected a DanglingSymlink after one hop, got %#v after %#v", err, chain)
+		}
+
+		loop := expect(temp.Join("loop").AsSymlink().LinkTo("./loop"))
+		chain, err = hops(loop)
+		var l pathlib.SymlinkLoop
+		if !errors.As(err, &l) || !slices.Equal(l.Chain, []pathlib.Symlink{loop, loop.Join().AsSymlink()}) {
+			t.Errorf("expected a SymlinkLoop, got %#v after %#v", err, chain)
+		}
+	})
+}
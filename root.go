@@ -0,0 +1,192 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Returned by [Root.Join] when a path would leave the root directory.
+var ErrEscapesRoot = errors.New("pathlib: path escapes from the root directory")
+
+// A directory that confines operations on relative names to itself, even through
+// ".." and symlinks, e.g. when extracting untrusted archives. See [os.Root].
+//
+// Names passed to a Root's methods must be relative. Returned paths name the same
+// objects outside the Root, but using them directly offers no protection, since a
+// symlink could be swapped in afterwards.
+type Root struct {
+	root *os.Root
+	dir  Dir
+}
+
+// Opens the directory as a [Root]. Only directories on the OS filesystem can be
+// opened; other backends fail with [errors.ErrUnsupported].
+func (d Dir) OpenRoot() (*Root, error) {
+	if _, ok := filesystemOf(string(d)).(OS); !ok {
+		return nil, &fs.PathError{Op: "openroot", Path: string(d), Err: errors.ErrUnsupported}
+	}
+	root, err := os.OpenRoot(string(d))
+	if err != nil {
+		return nil, err
+	}
+	return &Root{root, d}, nil
+}
+
+// Returns the directory the Root was opened from.
+func (r *Root) Dir() Dir {
+	return r.dir
+}
+
+// See [os.Root.Close].
+func (r *Root) Close() error {
+	return r.root.Close()
+}
+
+// See [os.Root.FS].
+func (r *Root) FS() fs.FS {
+	return r.root.FS()
+}
+
+// Joins the parts onto the root directory, failing with [ErrEscapesRoot] if the
+// result would lexically leave it. Symlinks are only checked by the Root's methods.
+func (r *Root) Join(parts ...string) (PathStr, error) {
+	name := hostFlavor.join(parts...)
+	if !hostFlavor.isLocal(name) {
+		return "", &fs.PathError{Op: "join", Path: name, Err: ErrEscapesRoot}
+	}
+	return r.dir.Join(name), nil
+}
+
+// Opens a file for reading. See [os.Root.Open].
+func (r *Root) Open(name string) (FileHandle, error) {
+	return r.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// See [os.Root.OpenFile] and [File.Open].
+func (r *Root) OpenFile(name string, flag int, perm fs.FileMode) (FileHandle, error) {
+	f, err := r.root.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &handle{f}, nil
+}
+
+// Opens a subdirectory as a [Root] that is confined to it. See [os.Root.OpenRoot].
+func (r *Root) OpenRoot(name string) (*Root, error) {
+	root, err := r.root.OpenRoot(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Root{root, Dir(r.dir.Join(name))}, nil
+}
+
+func rootInfo[P Kind](r *Root, name string, follow bool) (Info[P], error) {
+	var info fs.FileInfo
+	var err error
+	if follow {
+		info, err = r.root.Stat(name)
+	} else {
+		info, err = r.root.Lstat(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return onDisk[P]{P(r.dir.Join(name)), info}, nil
+}
+
+// See [os.Root.Stat].
+func (r *Root) Stat(name string) (Info[PathStr], error) {
+	return rootInfo[PathStr](r, name, true)
+}
+
+// See [os.Root.Lstat].
+func (r *Root) Lstat(name string) (Info[PathStr], error) {
+	return rootInfo[PathStr](r, name, false)
+}
+
+// Like [Dir.Stat], failing with [WrongTypeOnDisk] if the name isn't a directory.
+func (r *Root) StatDir(name string) (Info[Dir], error) {
+	return expectDir(rootInfo[Dir](r, name, true))
+}
+
+// Like [File.Stat], failing with [WrongTypeOnDisk] if the name isn't a regular file.
+func (r *Root) StatFile(name string) (Info[File], error) {
+	return expectFile(rootInfo[File](r, name, true))
+}
+
+// Like [Symlink.Lstat], failing with [WrongTypeOnDisk] if the name isn't a symlink.
+func (r *Root) LstatSymlink(name string) (Info[Symlink], error) {
+	return expectSymlink(rootInfo[Symlink](r, name, false))
+}
+
+// fail with [WrongTypeOnDisk] if a successful observation has the wrong type.
+
+func expectDir(info Info[Dir], err error) (Info[Dir], error) {
+	if err == nil && !info.IsDir() {
+		return nil, WrongTypeOnDisk[Dir]{info}
+	}
+	return info, err
+}
+
+func expectFile(info Info[File], err error) (Info[File], error) {
+	if err == nil && !info.Mode().IsRegular() {
+		return nil, WrongTypeOnDisk[File]{info}
+	}
+	return info, err
+}
+
+func expectSymlink(info Info[Symlink], err error) (Info[Symlink], error) {
+	if err == nil && info.Mode()&fs.ModeSymlink == 0 {
+		return nil, WrongTypeOnDisk[Symlink]{info}
+	}
+	return info, err
+}
+
+// See [os.Root.Mkdir].
+func (r *Root) Make(name string, perm fs.FileMode) (Dir, error) {
+	return Dir(r.dir.Join(name)), r.root.Mkdir(name, perm)
+}
+
+// Makes the directory and any missing parents, like [Dir.MakeAll]. Every component
+// is created through the Root.
+func (r *Root) MakeAll(name string, perm fs.FileMode) (Dir, error) {
+	result := Dir(r.dir.Join(name))
+	var prefix string
+	for _, part := range hostFlavor.parts(name) {
+		prefix = hostFlavor.join(prefix, part)
+		if err := r.root.Mkdir(prefix, perm); err != nil && !errors.Is(err, fs.ErrExist) {
+			return result, err
+		}
+	}
+	_, err := r.StatDir(name)
+	return result, err
+}
+
+// See [os.Root.Remove].
+func (r *Root) Remove(name string) error {
+	return r.root.Remove(name)
+}
+
+// Renames oldName to newName, both resolved within the Root. The final component of
+// each name isn't followed if it's a symlink.
+func (r *Root) Rename(oldName, newName string) (PathStr, error) {
+	if err := rootRename(r.root, oldName, newName); err != nil {
+		return "", &os.LinkError{Op: "renameat", Old: oldName, New: newName, Err: err}
+	}
+	return r.dir.Join(newName), nil
+}
+
+// opens the parent directory of a lexically-local name within the root.
+func openRootParent(root *os.Root, name string) (*os.File, string, error) {
+	name = hostFlavor.clean(name)
+	if !hostFlavor.isLocal(name) {
+		return nil, "", ErrEscapesRoot
+	}
+	base := hostFlavor.base(name)
+	if base == "." {
+		return nil, "", ErrInvalidName
+	}
+	parent, err := root.Open(hostFlavor.dir(name))
+	return parent, base, err
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package pathlib
+
+import (
+	"errors"
+	"os"
+)
+
+func rootRename(root *os.Root, oldName, newName string) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,141 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleRoot() {
+	dir := pathlib.Dir(expect(os.MkdirTemp("", "pathlib-root-")))
+	defer dir.RemoveAll()
+	root := expect(dir.OpenRoot())
+	defer root.Close()
+
+	for _, name := range []string{"docs/readme.txt", "../../etc/passwd"} {
+		if _, err := root.Join(name); err != nil {
+			fmt.Println("rejected", name)
+			continue
+		}
+		expect(root.MakeAll(pathlib.PathStr(name).Parent().String(), 0o755))
+		h := expect(root.OpenFile(name, os.O_WRONLY|os.O_CREATE, 0o644))
+		enforce(h.Close())
+		fmt.Println("wrote", expect(root.StatFile(name)).Path().BaseName())
+	}
+	// Output:
+	// wrote readme.txt
+	// rejected ../../etc/passwd
+}
+
+func TestRoot(t *testing.T) {
+	outside := pathlib.Dir(t.TempDir())
+	dir := expect(pathlib.Dir(t.TempDir()).Join("root").AsDir().Make(0o755))
+	writeFile(t, outside.Join("secret").AsFile(), "secret")
+	writeFile(t, dir.Join("file").AsFile(), "content")
+	expect(dir.Join("escape").AsSymlink().LinkTo(outside.Join("secret")))
+	expect(dir.Join("inside").AsSymlink().LinkTo("file"))
+
+	root := expect(dir.OpenRoot())
+	defer root.Close()
+	if root.Dir() != dir {
+		t.Errorf("expected %q, got %q", dir, root.Dir())
+	}
+
+	if p := expect(root.Join("a", "..", "b")); p != dir.Join("b") {
+		t.Errorf("unexpected join %q", p)
+	}
+	for _, parts := range [][]string{{".."}, {"a", "..", ".."}, {string(outside)}} {
+		if _, err := root.Join(parts...); !errors.Is(err, pathlib.ErrEscapesRoot) {
+			t.Errorf("Join(%q): expected ErrEscapesRoot, got %v", parts, err)
+		}
+	}
+
+	for _, name := range []string{"../" + outside.BaseName() + "/secret", "escape", string(outside.Join("secret"))} {
+		if h, err := root.Open(name); err == nil {
+			h.Close()
+			t.Errorf("Open(%q): expected the root to refuse", name)
+		}
+	}
+
+	h := expect(root.Open("inside"))
+	if content := string(expect(io.ReadAll(h))); content != "content" {
+		t.Errorf("unexpected content %q", content)
+	}
+	enforce(h.Close())
+	if h.Path() != dir.Join("inside").AsFile() {
+		t.Errorf("unexpected handle path %q", h.Path())
+	}
+
+	if info := expect(root.StatFile("inside")); info.Path() != dir.Join("inside").AsFile() {
+		t.Errorf("unexpected info path %q", info.Path())
+	}
+	expect(root.LstatSymlink("inside"))
+	expect(root.LstatSymlink("escape"))
+	expect(root.Lstat("escape"))
+	var wrongDir pathlib.WrongTypeOnDisk[pathlib.Dir]
+	if _, err := root.StatDir("file"); !errors.As(err, &wrongDir) {
+		t.Errorf("expected WrongTypeOnDisk, got %v", err)
+	}
+	var wrongLink pathlib.WrongTypeOnDisk[pathlib.Symlink]
+	if _, err := root.LstatSymlink("file"); !errors.As(err, &wrongLink) {
+		t.Errorf("expected WrongTypeOnDisk, got %v", err)
+	}
+	if _, err := root.Stat("escape"); err == nil {
+		t.Error("expected Stat to refuse to follow a symlink out of the root")
+	}
+
+	made := expect(root.MakeAll("a/b/c", 0o755))
+	if made != dir.Join("a", "b", "c").AsDir() || !made.Exists() {
+		t.Errorf("expected %q to be made", made)
+	}
+	expect(root.MakeAll("a/b/c", 0o755))
+	if _, err := root.MakeAll("file/x", 0o755); err == nil {
+		t.Error("expected an error when a parent is a file")
+	}
+	expect(root.Make("d", 0o755))
+
+	sub := expect(root.OpenRoot("a"))
+	defer sub.Close()
+	if sub.Dir() != dir.Join("a").AsDir() {
+		t.Errorf("unexpected sub-root %q", sub.Dir())
+	}
+	if _, err := sub.Stat("../file"); err == nil {
+		t.Error("expected the sub-root to be confined")
+	}
+
+	moved := expect(root.Rename("file", "a/b/moved"))
+	if moved != dir.Join("a", "b", "moved") || dir.Join("file").Exists() {
+		t.Errorf("unexpected rename result %q", moved)
+	}
+	expect(root.Rename("escape", "d/escape"))
+	if _, err := dir.Join("d", "escape").AsSymlink().Lstat(); err != nil {
+		t.Errorf("expected the symlink itself to move: %v", err)
+	}
+	if !outside.Join("secret").Exists() {
+		t.Error("expected the symlink's target to stay put")
+	}
+	for _, names := range [][2]string{{"a/b/moved", "../moved"}, {"../root/a", "x"}, {".", "x"}} {
+		if _, err := root.Rename(names[0], names[1]); err == nil {
+			t.Errorf("Rename(%q, %q): expected an error", names[0], names[1])
+		}
+	}
+
+	enforce(root.Remove("d/escape"))
+	if err := root.Remove("d/escape"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+	if content := string(expect(fs.ReadFile(root.FS(), "a/b/moved"))); content != "content" {
+		t.Errorf("unexpected content %q", content)
+	}
+}
+
+func TestRoot_unsupported(t *testing.T) {
+	if _, err := memDir(t).OpenRoot(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build unix
+
+package pathlib
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func rootRename(root *os.Root, oldName, newName string) error {
+	oldParent, oldBase, err := openRootParent(root, oldName)
+	if err != nil {
+		return err
+	}
+	defer oldParent.Close()
+	newParent, newBase, err := openRootParent(root, newName)
+	if err != nil {
+		return err
+	}
+	defer newParent.Close()
+	return control(oldParent, func(oldFd uintptr) error {
+		return control(newParent, func(newFd uintptr) error {
+			return unix.Renameat(int(oldFd), oldBase, int(newFd), newBase)
+		})
+	})
+}
@@ -0,0 +1,29 @@
+//go:build !unix
+
+package pathlib
+
+import "io/fs"
+
+// Returns the ID of the device containing the observed file, if available.
+func deviceOf(info fs.FileInfo) (dev uint64, ok bool) {
+	if sys, isMem := info.Sys().(*MemStat); isMem {
+		return sys.Dev, true
+	}
+	return 0, false
+}
+
+// Returns the observed file's owner and group, if available.
+func ownerOf(info fs.FileInfo) (uid, gid int, ok bool) {
+	if sys, isMem := info.Sys().(*MemStat); isMem {
+		return sys.Uid, sys.Gid, true
+	}
+	return 0, 0, false
+}
+
+// Returns the observed file's device and inode numbers, if available.
+func inodeOf(info fs.FileInfo) (dev, ino uint64, ok bool) {
+	if sys, isMem := info.Sys().(*MemStat); isMem {
+		return sys.Dev, sys.Ino, true
+	}
+	return 0, 0, false
+}
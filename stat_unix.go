@@ -0,0 +1,78 @@
+//go:build unix
+
+package pathlib
+
+import (
+	"io/fs"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Returns the ID of the device containing the observed file, if available.
+func deviceOf(info fs.FileInfo) (dev uint64, ok bool) {
+	switch sys := info.Sys().(type) {
+	case *MemStat:
+		return sys.Dev, true
+	case *syscall.Stat_t:
+		return uint64(sys.Dev), true
+	case *unix.Stat_t:
+		return uint64(sys.Dev), true
+	}
+	return 0, false
+}
+
+// Returns the observed file's owner and group, if available.
+func ownerOf(info fs.FileInfo) (uid, gid int, ok bool) {
+	switch sys := info.Sys().(type) {
+	case *MemStat:
+		return sys.Uid, sys.Gid, true
+	case *syscall.Stat_t:
+		return int(sys.Uid), int(sys.Gid), true
+	case *unix.Stat_t:
+		return int(sys.Uid), int(sys.Gid), true
+	}
+	return 0, 0, false
+}
+
+// Returns the observed file's device and inode numbers, if available.
+func inodeOf(info fs.FileInfo) (dev, ino uint64, ok bool) {
+	switch sys := info.Sys().(type) {
+	case *MemStat:
+		return sys.Dev, sys.Ino, true
+	case *syscall.Stat_t:
+		return uint64(sys.Dev), uint64(sys.Ino), true
+	case *unix.Stat_t:
+		return uint64(sys.Dev), uint64(sys.Ino), true
+	}
+	return 0, 0, false
+}
+
+// converts a st_mode into an [fs.FileMode], like package os does.
+func statMode(mode uint32) fs.FileMode {
+	result := fs.FileMode(mode & 0o777)
+	switch mode & syscall.S_IFMT {
+	case syscall.S_IFBLK:
+		result |= fs.ModeDevice
+	case syscall.S_IFCHR:
+		result |= fs.ModeDevice | fs.ModeCharDevice
+	case syscall.S_IFDIR:
+		result |= fs.ModeDir
+	case syscall.S_IFIFO:
+		result |= fs.ModeNamedPipe
+	case syscall.S_IFLNK:
+		result |= fs.ModeSymlink
+	case syscall.S_IFSOCK:
+		result |= fs.ModeSocket
+	}
+	if mode&syscall.S_ISGID != 0 {
+		result |= fs.ModeSetgid
+	}
+	if mode&syscall.S_ISUID != 0 {
+		result |= fs.ModeSetuid
+	}
+	if mode&syscall.S_ISVTX != 0 {
+		result |= fs.ModeSticky
+	}
+	return result
+}
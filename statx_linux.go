@@ -0,0 +1,220 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Linux-specific metadata. On Linux, every [Info] returned by a Stat or Lstat method
+// implements LinuxInfo. For paths served by the [OS] backend, it is populated by a
+// single statx(2) call; other backends report what their [fs.FileInfo.Sys] provides.
+// [OS.Stat] and [OS.Lstat] themselves return package os's infos, so [os.SameFile]
+// keeps working on them.
+type LinuxInfo interface {
+	fs.FileInfo
+	// The inode number.
+	Inode() uint64
+	// The ID of the device containing the file.
+	Device() uint64
+	// The number of hard links to the file.
+	Nlink() uint64
+	// The file's owner.
+	Uid() int
+	// The file's group.
+	Gid() int
+	// The time of last access.
+	AccessTime() time.Time
+	// The time of the last change to the file's metadata or contents.
+	ChangeTime() time.Time
+	// The file's creation time, if the filesystem records it.
+	BirthTime() (btime time.Time, ok bool)
+	// The ID of the mount containing the file, as in /proc/self/mountinfo, if known.
+	MountID() (id uint64, ok bool)
+	// The file's STATX_ATTR_* flags, such as [unix.STATX_ATTR_IMMUTABLE], and the mask
+	// of flags that the filesystem supports.
+	Attributes() (attrs, supported uint64)
+}
+
+var _ LinuxInfo = onDisk[PathStr]{}
+
+// Inode implements [LinuxInfo].
+func (p onDisk[P]) Inode() uint64 {
+	_, ino, _ := inodeOf(p.FileInfo)
+	return ino
+}
+
+// Device implements [LinuxInfo].
+func (p onDisk[P]) Device() uint64 {
+	dev, _ := deviceOf(p.FileInfo)
+	return dev
+}
+
+// Nlink implements [LinuxInfo].
+func (p onDisk[P]) Nlink() uint64 {
+	switch sys := p.Sys().(type) {
+	case *MemStat:
+		return sys.Nlink
+	case *syscall.Stat_t:
+		return uint64(sys.Nlink)
+	case *unix.Stat_t:
+		return uint64(sys.Nlink)
+	}
+	return 1
+}
+
+// Uid implements [LinuxInfo].
+func (p onDisk[P]) Uid() int {
+	uid, _, _ := ownerOf(p.FileInfo)
+	return uid
+}
+
+// Gid implements [LinuxInfo].
+func (p onDisk[P]) Gid() int {
+	_, gid, _ := ownerOf(p.FileInfo)
+	return gid
+}
+
+// AccessTime implements [LinuxInfo].
+func (p onDisk[P]) AccessTime() time.Time {
+	atime, _ := accessTime(p.FileInfo)
+	return atime
+}
+
+// ChangeTime implements [LinuxInfo].
+func (p onDisk[P]) ChangeTime() time.Time {
+	switch sys := p.Sys().(type) {
+	case *MemStat:
+		return sys.Ctime
+	case *syscall.Stat_t:
+		return time.Unix(sys.Ctim.Unix())
+	case *unix.Stat_t:
+		return time.Unix(sys.Ctim.Unix())
+	}
+	return time.Time{}
+}
+
+// BirthTime implements [LinuxInfo].
+func (p onDisk[P]) BirthTime() (time.Time, bool) {
+	if x, ok := p.FileInfo.(statxInfo); ok && x.statx.Mask&unix.STATX_BTIME != 0 {
+		return statxTime(x.statx.Btime), true
+	}
+	return time.Time{}, false
+}
+
+// MountID implements [LinuxInfo].
+func (p onDisk[P]) MountID() (uint64, bool) {
+	if x, ok := p.FileInfo.(statxInfo); ok && x.statx.Mask&unix.STATX_MNT_ID != 0 {
+		return x.statx.Mnt_id, true
+	}
+	return 0, false
+}
+
+// Attributes implements [LinuxInfo].
+func (p onDisk[P]) Attributes() (attrs, supported uint64) {
+	if x, ok := p.FileInfo.(statxInfo); ok {
+		return x.statx.Attributes, x.statx.Attributes_mask
+	}
+	return 0, 0
+}
+
+// statx(2) -------------------------------------------------------------------
+
+// An [fs.FileInfo] built from statx(2). Sys returns a [*syscall.Stat_t], like [os.Stat].
+type statxInfo struct {
+	name  string
+	sys   *syscall.Stat_t
+	statx *unix.Statx_t
+}
+
+func (i statxInfo) Name() string       { return i.name }
+func (i statxInfo) Size() int64        { return i.sys.Size }
+func (i statxInfo) Mode() fs.FileMode  { return statMode(uint32(i.statx.Mode)) }
+func (i statxInfo) ModTime() time.Time { return time.Unix(i.sys.Mtim.Unix()) }
+func (i statxInfo) IsDir() bool        { return i.Mode().IsDir() }
+func (i statxInfo) Sys() any           { return i.sys }
+
+const statxMask = unix.STATX_BASIC_STATS | unix.STATX_BTIME | unix.STATX_MNT_ID
+
+// Like [os.Stat] or [os.Lstat], but also collects statx(2)'s extra fields. Falls back
+// to package os where statx is unavailable. The result isn't understood by
+// [os.SameFile]; use [sameFile].
+func osStat(name string, follow bool) (fs.FileInfo, error) {
+	info, err := statxAt(unix.AT_FDCWD, name, follow)
+	if errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.EPERM) {
+		// old kernels, or sandboxes that filter statx
+		if follow {
+			return os.Stat(name)
+		}
+		return os.Lstat(name)
+	} else if err != nil {
+		op := "stat"
+		if !follow {
+			op = "lstat"
+		}
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// See [DirHandle.Stat].
+func fstatat(dirfd int, name string, follow bool) (fs.FileInfo, error) {
+	info, err := statxAt(dirfd, name, follow)
+	if errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.EPERM) {
+		return fstatatInfo(dirfd, name, follow)
+	}
+	return info, err
+}
+
+// calls statx(2) relative to dirfd, returning the bare errno on failure.
+func statxAt(dirfd int, name string, follow bool) (fs.FileInfo, error) {
+	flags := unix.AT_STATX_SYNC_AS_STAT
+	if !follow {
+		flags |= unix.AT_SYMLINK_NOFOLLOW
+	}
+	var x unix.Statx_t
+	var err error
+	for {
+		err = unix.Statx(dirfd, name, flags, statxMask, &x)
+		if err != unix.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statxInfo{name: filepath.Base(name), sys: statT(&x), statx: &x}, nil
+}
+
+func statxTime(t unix.StatxTimestamp) time.Time {
+	return time.Unix(t.Sec, int64(t.Nsec))
+}
+
+// converts statx(2)'s result into the struct that stat(2) would have filled in.
+func statT(x *unix.Statx_t) *syscall.Stat_t {
+	// Stat_t's field types vary between architectures
+	var st syscall.Stat_t
+	setInt(&st.Dev, unix.Mkdev(x.Dev_major, x.Dev_minor))
+	setInt(&st.Ino, x.Ino)
+	setInt(&st.Nlink, uint64(x.Nlink))
+	setInt(&st.Mode, uint64(x.Mode))
+	setInt(&st.Uid, uint64(x.Uid))
+	setInt(&st.Gid, uint64(x.Gid))
+	setInt(&st.Rdev, unix.Mkdev(x.Rdev_major, x.Rdev_minor))
+	setInt(&st.Size, x.Size)
+	setInt(&st.Blksize, uint64(x.Blksize))
+	setInt(&st.Blocks, x.Blocks)
+	st.Atim = syscall.NsecToTimespec(statxTime(x.Atime).UnixNano())
+	st.Mtim = syscall.NsecToTimespec(statxTime(x.Mtime).UnixNano())
+	st.Ctim = syscall.NsecToTimespec(statxTime(x.Ctime).UnixNano())
+	return &st
+}
+
+func setInt[T ~int32 | ~int64 | ~uint32 | ~uint64](dst *T, v uint64) {
+	*dst = T(v)
+}
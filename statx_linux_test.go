@@ -0,0 +1,90 @@
+package pathlib_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func TestInfo_linux(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		before := time.Now().Add(-time.Second)
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		expect(dir.Join("sub").AsDir().Make(0o755))
+		file := expect(dir.Join("file.txt").AsFile().WriteString("hello", 0o644))
+
+		info, ok := expect(dir.Stat()).(pathlib.LinuxInfo)
+		if !ok {
+			t.Fatal("expected Info to implement LinuxInfo")
+		}
+		if n := info.Nlink(); n != 3 {
+			t.Errorf("expected a directory with one subdirectory to have 3 links, got %d", n)
+		}
+		fileInfo := expect(file.Lstat()).(pathlib.LinuxInfo)
+		if fileInfo.Inode() == info.Inode() || fileInfo.Device() != info.Device() {
+			t.Errorf("unexpected identity: inode %d on device %d", fileInfo.Inode(), fileInfo.Device())
+		}
+		if fileInfo.Nlink() != 1 {
+			t.Errorf("expected 1 link, got %d", fileInfo.Nlink())
+		}
+		if uid, gid := fileInfo.Uid(), fileInfo.Gid(); uid != syscall.Getuid() || gid != syscall.Getgid() {
+			t.Errorf("unexpected ownership %d:%d", uid, gid)
+		}
+		for name, ts := range map[string]time.Time{"atime": fileInfo.AccessTime(), "ctime": fileInfo.ChangeTime()} {
+			if ts.Before(before) {
+				t.Errorf("expected %s after %s, got %s", name, before, ts)
+			}
+		}
+		if btime, ok := fileInfo.BirthTime(); ok && btime.Before(before) {
+			t.Errorf("expected a birth time after %s, got %s", before, btime)
+		}
+	})
+}
+
+func TestInfo_statx(t *testing.T) {
+	file := expect(pathlib.Dir(t.TempDir()).Join("file.txt").AsFile().WriteString("", 0o644))
+	info := expect(file.Stat()).(pathlib.LinuxInfo)
+	if _, ok := info.Sys().(*syscall.Stat_t); !ok {
+		t.Errorf("expected Sys to return a *syscall.Stat_t, got %T", info.Sys())
+	}
+	if _, ok := info.MountID(); !ok {
+		t.Skip("statx(2) doesn't report mount IDs here")
+	}
+	var st syscall.Stat_t
+	enforce(syscall.Stat(file.String(), &st))
+	if info.Inode() != st.Ino || info.Size() != st.Size || !info.ModTime().Equal(time.Unix(st.Mtim.Unix())) {
+		t.Errorf("statx disagrees with stat: %+v vs %+v", info.Sys(), st)
+	}
+}
+
+func TestInfo_nlink(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		original := expect(temp.Join("original").AsFile().WriteString("", 0o644))
+		link := expect(temp.Join("link").AsFile().HardLinkTo(original))
+		nlink := func() uint64 { return expect(link.Stat()).(pathlib.LinuxInfo).Nlink() }
+		if n := nlink(); n != 2 {
+			t.Errorf("expected 2 links, got %d", n)
+		}
+		enforce(original.Remove())
+		if n := nlink(); n != 1 {
+			t.Errorf("expected 1 link after removing the original, got %d", n)
+		}
+		replacement := expect(temp.Join("replacement").AsFile().HardLinkTo(link))
+		expect(expect(temp.Join("other").AsFile().WriteString("", 0o644)).Rename(pathlib.PathStr(replacement)))
+		if n := nlink(); n != 1 {
+			t.Errorf("expected 1 link after renaming over the other, got %d", n)
+		}
+	})
+}
+
+func TestOS_Stat_sameFile(t *testing.T) {
+	file := expect(pathlib.Dir(t.TempDir()).Join("file.txt").AsFile().WriteString("", 0o644))
+	a := expect(pathlib.OS{}.Stat(file.String()))
+	b := expect(pathlib.OS{}.Lstat(file.String()))
+	if !os.SameFile(a, b) {
+		t.Error("expected os.SameFile to understand the OS backend's infos")
+	}
+}
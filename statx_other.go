@@ -0,0 +1,16 @@
+//go:build !linux
+
+package pathlib
+
+import (
+	"io/fs"
+	"os"
+)
+
+// See [os.Stat] and [os.Lstat].
+func osStat(name string, follow bool) (fs.FileInfo, error) {
+	if follow {
+		return os.Stat(name)
+	}
+	return os.Lstat(name)
+}
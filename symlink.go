@@ -4,17 +4,16 @@ import (
 	"errors"
 	"io/fs"
 	"os"
+	"time"
 )
 
 type Symlink PathStr
 
 // See [os.Symlink].
 func (s Symlink) LinkTo(target PathStr) (Symlink, error) {
-	return s, os.Symlink(target.String(), s.String())
+	return s, symlink(s, target)
 }
 
-// TODO: iterator over chained symlinks
-
 // Readable --------------------------------------------------------------------
 var _ Readable[PathStr] = Symlink("./link")
 
@@ -24,12 +23,12 @@ var _ Readable[PathStr] = Symlink("./link")
 //
 // Read implements [Readable].
 func (s Symlink) Read() (PathStr, error) {
-	link, err := os.Readlink(string(s))
-	return PathStr(link), err
+	return readlink(s)
 }
 
 // -----------------------------------------------------------------------------
 var _ PurePath = Symlink("./link")
+var _ Stemmer = Symlink("./link")
 
 // BaseName implements [PurePath].
 func (s Symlink) BaseName() string {
@@ -67,6 +66,7 @@ func (s Symlink) Ext() string {
 
 // -----------------------------------------------------------------------------
 var _ Transformer[Symlink] = Symlink("./link")
+var _ PureTransformer[Symlink] = Symlink("./link")
 
 // Convenience method to cast get the untyped string representation of the path.
 //
@@ -120,6 +120,49 @@ func (s Symlink) ExpandUser() (Symlink, error) {
 	return expandUser(s)
 }
 
+// Stem implements [Stemmer].
+func (s Symlink) Stem() string {
+	return stem(s)
+}
+
+// Suffixes implements [Stemmer].
+func (s Symlink) Suffixes() []string {
+	return suffixes(s)
+}
+
+// IsRelativeTo implements [PureTransformer].
+func (s Symlink) IsRelativeTo(base Dir) bool {
+	return isRelativeTo(s, base)
+}
+
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (s Symlink) WithName(name string) (Symlink, error) {
+	return withName(s, name)
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (s Symlink) WithStem(stem string) (Symlink, error) {
+	return withStem(s, stem)
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (s Symlink) WithSuffix(suffix string) (Symlink, error) {
+	return withSuffix(s, suffix)
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (s Symlink) RelativeTo(base Dir) (Symlink, error) {
+	return relativeTo(s, base)
+}
+
 // Beholder --------------------------------------------------------------------
 var _ Beholder[Symlink] = Symlink("./link")
 
@@ -162,6 +205,7 @@ func (s Symlink) Stat() (Info[Symlink], error) {
 
 // Changer ----------------------------------------------------------------------
 var _ Changer = Symlink("./link")
+var _ TimeChanger = Symlink("./link")
 
 // See [os.Chmod].
 //
@@ -177,6 +221,13 @@ func (s Symlink) Chown(uid int, gid int) error {
 	return chown(s, uid, gid)
 }
 
+// Sets the times of the link itself rather than its target. See lutimes(3).
+//
+// Chtimes implements [TimeChanger].
+func (s Symlink) Chtimes(atime, mtime time.Time) error {
+	return chtimes(s, atime, mtime, false)
+}
+
 // Mover ------------------------------------------------------------------------
 var _ Remover[Symlink] = Symlink("./link")
 
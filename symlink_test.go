@@ -42,57 +42,59 @@ func ExampleSymlink_Lstat() {
 }
 
 func TestSymlink_beholder(t *testing.T) {
-	temp := pathlib.Dir(t.TempDir())
-	file := temp.Join("file.txt")
-	expect(file.AsFile().Make(0644))
-	symlink := expect(temp.Join("nested/dir").
-		AsDir().
-		MakeAll(0777, 0777)).
-		Join("link").AsSymlink()
-
-	expect(symlink.LinkTo("../../file.txt"))
-	stat := expect(symlink.Stat())
-	if !stat.Mode().IsRegular() || stat.Mode().Perm() != 0644 {
-		t.Fatalf("stat: %o", stat.Mode())
-	}
-	lstat := expect(symlink.Lstat())
-	if lstat.Mode().IsRegular() {
-		t.Fail()
-	}
-	if !symlink.Exists() {
-		t.Fail()
-	}
-	if expect(symlink.Lstat()).Mode().IsRegular() {
-		t.Fail()
-	}
-	_, err := file.AsSymlink().Lstat()
-	if err == nil {
-		t.Fail()
-	}
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := temp.Join("file.txt")
+		expect(file.AsFile().Make(0644))
+		symlink := expect(temp.Join("nested/dir").
+			AsDir().
+			MakeAll(0777, 0777)).
+			Join("link").AsSymlink()
+
+		expect(symlink.LinkTo("../../file.txt"))
+		stat := expect(symlink.Stat())
+		if !stat.Mode().IsRegular() || stat.Mode().Perm() != 0644 {
+			t.Fatalf("stat: %o", stat.Mode())
+		}
+		lstat := expect(symlink.Lstat())
+		if lstat.Mode().IsRegular() {
+			t.Fail()
+		}
+		if !symlink.Exists() {
+			t.Fail()
+		}
+		if expect(symlink.Lstat()).Mode().IsRegular() {
+			t.Fail()
+		}
+		_, err := file.AsSymlink().Lstat()
+		if err == nil {
+			t.Fail()
+		}
+	})
 }
 
 func TestSymlink_linkChasing(t *testing.T) {
-	temp := pathlib.Dir(t.TempDir())
-	file := temp.Join("file.txt")
-	handle := expect(file.AsFile().Make(0644))
-	content := "example"
-	expect(handle.WriteString(content))
-	enforce(handle.Close())
-	link1 := expect(temp.Join("nested/dir").
-		AsDir().
-		MakeAll(0777, 0777)).
-		Join("link1").AsSymlink()
-	expect(link1.LinkTo("../../file.txt"))
-	link2 := expect(temp.Join("link2").AsSymlink().LinkTo("nested/dir/link1"))
-
-	data := expect(link2.Join().AsFile().Read())
-	if string(data) != "example" {
-		t.Fatal(string(data))
-	}
-	info := expect(link2.Stat())
-	if info.Size() != int64(len(content)) {
-		t.Fatalf("unexpected info: %#v", info)
-	}
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := temp.Join("file.txt")
+		handle := expect(file.AsFile().Make(0644))
+		content := "example"
+		expect(handle.WriteString(content))
+		enforce(handle.Close())
+		link1 := expect(temp.Join("nested/dir").
+			AsDir().
+			MakeAll(0777, 0777)).
+			Join("link1").AsSymlink()
+		expect(link1.LinkTo("../../file.txt"))
+		link2 := expect(temp.Join("link2").AsSymlink().LinkTo("nested/dir/link1"))
+
+		data := expect(link2.Join().AsFile().Read())
+		if string(data) != "example" {
+			t.Fatal(string(data))
+		}
+		info := expect(link2.Stat())
+		if info.Size() != int64(len(content)) {
+			t.Fatalf("unexpected info: %#v", info)
+		}
+	})
 }
 
 func TestSymlink_transformer(t *testing.T) {
@@ -195,29 +197,30 @@ func TestSymlink_localize(t *testing.T) {
 }
 
 func TestSymlink_mover(t *testing.T) {
-	temp := pathlib.Dir(t.TempDir())
-	file := temp.Join("file.txt")
-	expect(file.AsFile().Make(0666))
-	symlink := expect(temp.Join("link").AsSymlink().LinkTo(file))
-
-	renamed := expect(symlink.Rename(temp.Join("renamed")))
-	if symlink.Exists() {
-		t.Fatal("renaming link should remove the original link")
-	}
-	if !renamed.Exists() {
-		t.Fatal("renamed link should exist")
-	}
-	if !file.Exists() {
-		t.Fatal("renaming symlink should not affect target file")
-	}
-	if expect(renamed.Read()) != file {
-		t.Fatal("renamed link should point to the original target")
-	}
-	enforce(renamed.Remove())
-	if renamed.Exists() {
-		t.Fatal("removing link should remove the link")
-	}
-	if !file.Exists() {
-		t.Fatal("removing link should not affect target file")
-	}
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := temp.Join("file.txt")
+		expect(file.AsFile().Make(0666))
+		symlink := expect(temp.Join("link").AsSymlink().LinkTo(file))
+
+		renamed := expect(symlink.Rename(temp.Join("renamed")))
+		if symlink.Exists() {
+			t.Fatal("renaming link should remove the original link")
+		}
+		if !renamed.Exists() {
+			t.Fatal("renamed link should exist")
+		}
+		if !file.Exists() {
+			t.Fatal("renaming symlink should not affect target file")
+		}
+		if expect(renamed.Read()) != file {
+			t.Fatal("renamed link should point to the original target")
+		}
+		enforce(renamed.Remove())
+		if renamed.Exists() {
+			t.Fatal("removing link should remove the link")
+		}
+		if !file.Exists() {
+			t.Fatal("removing link should not affect target file")
+		}
+	})
 }
@@ -0,0 +1,124 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var errPatternHasSeparator = errors.New("pattern contains path separator")
+
+// Create a new directory inside d with a unique name, like [os.MkdirTemp]. The last "*"
+// in pattern is replaced by a random string; without one, the random string is
+// appended. If d is empty, the directory is created in [TempDir]. The caller is
+// responsible for removing the directory.
+func (d Dir) MkdirTemp(pattern string) (Dir, error) {
+	var result Dir
+	err := tempName(d, "mkdirtemp", pattern, func(name PathStr) (err error) {
+		result, err = Dir(name).Make(0o700)
+		return
+	})
+	return result, err
+}
+
+// Create and open a new file inside d with a unique name, like [os.CreateTemp]. See
+// [Dir.MkdirTemp] for how pattern is used. The caller is responsible for removing the
+// file.
+func (d Dir) CreateTemp(pattern string) (FileHandle, error) {
+	h, err := createTemp(d, pattern, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Create a temporary directory inside d, pass it to fn, then remove it and everything
+// in it. If the process receives an interrupt or termination signal while fn is
+// running, the directory is removed before the signal is re-delivered, so the
+// signal's default action or the program's own [signal.Notify] handlers still apply.
+// Where a process can't signal itself, as on Windows, WithTempDir instead returns an
+// error naming the signal once fn returns.
+//
+// Returns fn's error, or else the error from removing the directory.
+func (d Dir) WithTempDir(pattern string, fn func(temp Dir) error) (err error) {
+	temp, err := d.MkdirTemp(pattern)
+	if err != nil {
+		return err
+	}
+	signals := make(chan os.Signal, 1)
+	done, handled := make(chan struct{}), make(chan struct{})
+	var reraiseErr error // set if the signal couldn't be re-delivered
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer close(handled)
+		select {
+		case sig := <-signals:
+			_, _ = temp.RemoveAll()
+			signal.Stop(signals)
+			reraiseErr = reraise(sig)
+		case <-done:
+		}
+	}()
+	defer func() {
+		signal.Stop(signals)
+		close(done)
+		<-handled
+		if _, removeErr := temp.RemoveAll(); err == nil {
+			err = removeErr
+		}
+		if err == nil {
+			err = reraiseErr
+		}
+	}()
+	return fn(temp)
+}
+
+// delivers the signal again now that it's no longer being caught.
+func reraise(sig os.Signal) error {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+	if err != nil {
+		return fmt.Errorf("re-delivering %v: %w", sig, err)
+	}
+	return nil
+}
+
+func createTemp(d Dir, pattern string, perm fs.FileMode) (*handle, error) {
+	var result *handle
+	err := tempName(d, "createtemp", pattern, func(name PathStr) (err error) {
+		result, err = openFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		return
+	})
+	return result, err
+}
+
+// calls create with random names matching the pattern until it doesn't fail with
+// [fs.ErrExist].
+func tempName(d Dir, op, pattern string, create func(PathStr) error) error {
+	if d == "" {
+		d = TempDir()
+	}
+	if hostFlavor.hasSeparator(pattern) {
+		return &fs.PathError{Op: op, Path: pattern, Err: errPatternHasSeparator}
+	}
+	prefix, suffix := pattern, ""
+	if i := strings.LastIndexByte(pattern, '*'); i >= 0 {
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+	var err error
+	for range 10000 {
+		name := d.Join(prefix + strconv.FormatUint(uint64(rand.Uint32()), 10) + suffix)
+		if err = create(name); !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+	}
+	return &fs.PathError{Op: op, Path: string(d.Join(pattern)), Err: fs.ErrExist}
+}
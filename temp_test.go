@@ -0,0 +1,81 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDir_WithTempDir() {
+	err := pathlib.TempDir().WithTempDir("build-*", func(temp pathlib.Dir) error {
+		_, err := temp.Join("out.txt").AsFile().WriteString("artifact", 0o644)
+		return err
+	})
+	fmt.Println(err)
+	// Output:
+	// <nil>
+}
+
+func TestDir_MkdirTemp(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		dir := expect(temp.MkdirTemp("cache-*.d"))
+		if dir.Parent() != temp || !expect(filepath.Match("cache-*.d", dir.BaseName())) {
+			t.Errorf("unexpected name %q", dir)
+		}
+		if mode := expect(dir.Stat()).Mode(); mode != fs.ModeDir|0o700 {
+			t.Errorf("expected mode %s, got %s", fs.ModeDir|0o700, mode)
+		}
+		if other := expect(temp.MkdirTemp("cache-*.d")); other == dir {
+			t.Error("expected a unique name")
+		}
+		if suffixless := expect(temp.MkdirTemp("plain")); !strings.HasPrefix(suffixless.BaseName(), "plain") {
+			t.Errorf("expected the random string to be appended, got %q", suffixless)
+		}
+		if _, err := temp.MkdirTemp("a/*"); err == nil || !strings.Contains(err.Error(), "separator") {
+			t.Errorf("expected an error for a pattern with a separator, got %v", err)
+		}
+		if _, err := temp.Join("missing").AsDir().MkdirTemp("*"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestDir_CreateTemp(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		h := expect(temp.CreateTemp("*.json"))
+		defer func() { enforce(h.Close()) }()
+		if h.Parent() != temp || h.Ext() != ".json" {
+			t.Errorf("unexpected name %q", h.Path())
+		}
+		if mode := expect(h.Stat()).Mode(); mode != 0o600 {
+			t.Errorf("expected mode %s, got %s", fs.FileMode(0o600), mode)
+		}
+		expect(h.WriteString("{}"))
+		if data := expect(h.Path().ReadString()); data != "{}" {
+			t.Errorf("expected the handle to be writable, got %q", data)
+		}
+	})
+}
+
+func TestDir_WithTempDir(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		var used pathlib.Dir
+		oops := errors.New("oops")
+		err := temp.WithTempDir("scoped-*", func(dir pathlib.Dir) error {
+			used = dir
+			expect(dir.Join("a/b.txt").AsFile().MakeAll(0o644, 0o755))
+			return oops
+		})
+		if !errors.Is(err, oops) {
+			t.Errorf("expected the callback's error, got %v", err)
+		}
+		if used == "" || used.Exists() {
+			t.Errorf("expected %q to be removed", used)
+		}
+	})
+}
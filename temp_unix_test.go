@@ -0,0 +1,72 @@
+//go:build unix
+
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// The body of TestDir_WithTempDir_signal, run in a child process.
+func withTempDirUntilSignal(parent pathlib.Dir) {
+	_ = parent.WithTempDir("signaled-*", func(temp pathlib.Dir) error {
+		fmt.Println(temp)
+		enforce(syscall.Kill(os.Getpid(), syscall.SIGTERM))
+		time.Sleep(10 * time.Second)
+		return nil
+	})
+	os.Exit(0) // not reached: the signal should end the process
+}
+
+func TestDir_WithTempDir_signal(t *testing.T) {
+	if parent := os.Getenv("PATHLIB_SIGNAL_TEST_DIR"); parent != "" {
+		withTempDirUntilSignal(pathlib.Dir(parent))
+	}
+	parent := pathlib.Dir(t.TempDir())
+	cmd := exec.Command(os.Args[0], "-test.run=^TestDir_WithTempDir_signal$")
+	cmd.Env = append(os.Environ(), "PATHLIB_SIGNAL_TEST_DIR="+parent.String())
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) || exit.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+		t.Fatalf("expected the child to be killed by SIGTERM, got %v: %s", err, out)
+	}
+	temp := pathlib.Dir(strings.TrimSpace(string(out)))
+	if temp.Parent() != parent || temp.Exists() {
+		t.Errorf("expected %q to have been removed", temp)
+	}
+}
+
+func TestDir_WithTempDir_signalHandled(t *testing.T) {
+	// the program's own handler should see the re-delivered signal instead of the
+	// process being killed
+	handler := make(chan os.Signal, 2)
+	signal.Notify(handler, syscall.SIGTERM)
+	defer signal.Stop(handler)
+	var temp pathlib.Dir
+	err := pathlib.Dir(t.TempDir()).WithTempDir("handled-*", func(dir pathlib.Dir) error {
+		temp = dir
+		enforce(syscall.Kill(os.Getpid(), syscall.SIGTERM))
+		for dir.Exists() {
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	})
+	enforce(err)
+	if temp.Exists() {
+		t.Errorf("expected %q to have been removed", temp)
+	}
+	select {
+	case <-handler:
+	case <-time.After(5 * time.Second):
+		t.Error("expected the handler to receive SIGTERM")
+	}
+}
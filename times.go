@@ -0,0 +1,53 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+// Special values for [TimeChanger.Chtimes], like utimensat(2)'s UTIME_OMIT and UTIME_NOW.
+var (
+	// Leave the timestamp unchanged. This is the zero [time.Time], as with [os.Chtimes].
+	UtimeOmit = time.Time{}
+	// Set the timestamp to the current time. Unlike passing [time.Now], this only needs
+	// write permission rather than ownership, and uses the filesystem's clock.
+	UtimeNow = time.Time{}.Add(1)
+)
+
+// Changes a file's timestamps. Every path type in this package implements
+// TimeChanger alongside [Changer], as do [Info] and [FileHandle] values.
+type TimeChanger interface {
+	// Set the access and modification times. Pass [UtimeOmit] to leave a time
+	// unchanged or [UtimeNow] to set it to the current time. See utimensat(2).
+	Chtimes(atime, mtime time.Time) error
+}
+
+// Implemented by [Filesystem] backends that understand [UtimeOmit] and [UtimeNow] and
+// can set the times of a symlink itself. For other backends, [UtimeNow] is replaced
+// by [time.Now] and setting a symlink's own times fails with [errors.ErrUnsupported].
+type UtimesFilesystem interface {
+	// See utimensat(2). If follow is false, acts on a final symlink itself.
+	Utimes(name string, atime, mtime time.Time, follow bool) error
+}
+
+var _ UtimesFilesystem = OS{}
+
+func chtimes[P Kind](p P, atime, mtime time.Time, follow bool) error {
+	fsys := filesystemOf(string(p))
+	if u, ok := fsys.(UtimesFilesystem); ok {
+		return u.Utimes(string(p), atime, mtime, follow)
+	}
+	if !follow {
+		return &fs.PathError{Op: "lutimes", Path: string(p), Err: errors.ErrUnsupported}
+	}
+	return fsys.Chtimes(string(p), resolveUtime(atime), resolveUtime(mtime))
+}
+
+// replaces [UtimeNow] with the current time.
+func resolveUtime(t time.Time) time.Time {
+	if t.Equal(UtimeNow) {
+		return time.Now()
+	}
+	return t
+}
@@ -0,0 +1,27 @@
+package pathlib
+
+import (
+	"io/fs"
+	"os"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// sets the times of an open file, like futimens(3), which is utimensat(2) with a
+// NULL path.
+func futimes(f *os.File, atime, mtime time.Time) error {
+	ts := utimeSpecs(atime, mtime)
+	err := control(f, func(fd uintptr) error {
+		_, _, errno := unix.Syscall6(unix.SYS_UTIMENSAT, fd, 0, uintptr(unsafe.Pointer(&ts[0])), 0, 0, 0)
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	})
+	if err != nil {
+		return &fs.PathError{Op: "futimens", Path: f.Name(), Err: err}
+	}
+	return nil
+}
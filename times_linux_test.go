@@ -0,0 +1,27 @@
+package pathlib_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func TestFileHandle_Chtimes_renamed(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	file := expect(temp.Join("file").AsFile().WriteString("", 0o644))
+	h := expect(file.Open(os.O_RDONLY, 0))
+	defer func() { enforce(h.Close()) }()
+	renamed := expect(file.Rename(temp.Join("renamed")))
+
+	past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	enforce(h.(pathlib.TimeChanger).Chtimes(past, pathlib.UtimeOmit))
+	info := expect(renamed.Stat()).(pathlib.LinuxInfo)
+	if atime := info.AccessTime(); !atime.Equal(past) {
+		t.Errorf("expected the descriptor to be used, got access time %s", atime)
+	}
+	if info.ModTime().Equal(past) {
+		t.Error("expected UtimeOmit to keep the modification time")
+	}
+}
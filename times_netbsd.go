@@ -0,0 +1,7 @@
+package pathlib
+
+// from <sys/stat.h>, since golang.org/x/sys/unix doesn't define them for netbsd.
+const (
+	utimeNow  = 1<<30 - 1
+	utimeOmit = 1<<30 - 2
+)
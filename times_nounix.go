@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// Utimes implements [UtimesFilesystem].
+func (OS) Utimes(name string, atime, mtime time.Time, follow bool) error {
+	if !follow {
+		return &fs.PathError{Op: "lutimes", Path: name, Err: errors.ErrUnsupported}
+	}
+	return os.Chtimes(name, resolveUtime(atime), resolveUtime(mtime))
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !windows
+
+package pathlib
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// reports that setting times by descriptor isn't implemented, so the path is used.
+func futimes(*os.File, time.Time, time.Time) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,113 @@
+package pathlib_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleFile_Touch() {
+	dir := pathlib.Dir(expect(os.MkdirTemp("", "")))
+	defer func() { _, _ = dir.RemoveAll() }()
+
+	stamp := dir.Join("stamp").AsFile()
+	fmt.Println(stamp.Exists())
+	expect(stamp.Touch())
+	fmt.Println(stamp.Exists(), expect(stamp.Stat()).Size())
+	// Output:
+	// false
+	// true 0
+}
+
+func TestChtimes(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file").AsFile().WriteString("", 0o644))
+		past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		later := past.Add(time.Hour)
+		modTime := func() time.Time { return expect(file.Stat()).ModTime() }
+
+		enforce(file.Chtimes(past, past))
+		if mtime := modTime(); !mtime.Equal(past) {
+			t.Errorf("expected %s, got %s", past, mtime)
+		}
+		enforce(file.Chtimes(later, pathlib.UtimeOmit))
+		if mtime := modTime(); !mtime.Equal(past) {
+			t.Errorf("expected UtimeOmit to keep %s, got %s", past, mtime)
+		}
+		before := time.Now().Add(-time.Second)
+		enforce(pathlib.PathStr(file).Chtimes(pathlib.UtimeOmit, pathlib.UtimeNow))
+		if mtime := modTime(); mtime.Before(before) {
+			t.Errorf("expected UtimeNow to set a recent time, got %s", mtime)
+		}
+
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		enforce(dir.Chtimes(past, past))
+		if mtime := expect(dir.Stat()).ModTime(); !mtime.Equal(past) {
+			t.Errorf("expected %s, got %s", past, mtime)
+		}
+	})
+}
+
+func TestSymlink_Chtimes(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		target := expect(temp.Join("target").AsFile().WriteString("", 0o644))
+		link := expect(temp.Join("link").AsSymlink().LinkTo("target"))
+		past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		enforce(target.Chtimes(past, past))
+
+		enforce(link.Chtimes(past.Add(time.Hour), past.Add(time.Hour)))
+		if mtime := expect(link.Lstat()).ModTime(); !mtime.Equal(past.Add(time.Hour)) {
+			t.Errorf("expected the link's time to change, got %s", mtime)
+		}
+		if mtime := expect(target.Stat()).ModTime(); !mtime.Equal(past) {
+			t.Errorf("expected the target's time to stay %s, got %s", past, mtime)
+		}
+
+		enforce(expect(link.Lstat()).(pathlib.TimeChanger).Chtimes(past, past))
+		if mtime := expect(link.Lstat()).ModTime(); !mtime.Equal(past) {
+			t.Errorf("expected Info[Symlink] to change the link's time, got %s", mtime)
+		}
+	})
+}
+
+func TestFile_Touch(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file").AsFile().WriteString("keep", 0o600))
+		past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+		enforce(file.Chtimes(past, past))
+
+		before := time.Now().Add(-time.Second)
+		expect(file.Touch())
+		info := expect(file.Stat())
+		if info.ModTime().Before(before) {
+			t.Errorf("expected Touch to bump the modification time, got %s", info.ModTime())
+		}
+		if content := expect(file.ReadString()); content != "keep" {
+			t.Errorf("expected Touch to keep the contents, got %q", content)
+		}
+		if _, err := temp.Join("missing/file").AsFile().Touch(); err == nil {
+			t.Error("expected an error touching a file in a missing directory")
+		}
+	})
+}
+
+func TestFileHandle_Chtimes(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := expect(temp.Join("file").AsFile().WriteString("", 0o644))
+		h := expect(file.Open(os.O_RDONLY, 0))
+		defer func() { enforce(h.Close()) }()
+		past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+		enforce(h.(pathlib.TimeChanger).Chtimes(past, past))
+		if mtime := expect(file.Stat()).ModTime(); !mtime.Equal(past) {
+			t.Errorf("expected %s, got %s", past, mtime)
+		}
+		enforce(h.(pathlib.TimeChanger).Chtimes(pathlib.UtimeOmit, pathlib.UtimeOmit))
+		if mtime := expect(file.Stat()).ModTime(); !mtime.Equal(past) {
+			t.Errorf("expected UtimeOmit to keep %s, got %s", past, mtime)
+		}
+	})
+}
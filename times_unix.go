@@ -0,0 +1,37 @@
+//go:build unix
+
+package pathlib
+
+import (
+	"io/fs"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Utimes implements [UtimesFilesystem].
+func (OS) Utimes(name string, atime, mtime time.Time, follow bool) error {
+	op, flags := "utimensat", 0
+	if !follow {
+		op, flags = "lutimes", unix.AT_SYMLINK_NOFOLLOW
+	}
+	ts := utimeSpecs(atime, mtime)
+	if err := unix.UtimesNanoAt(unix.AT_FDCWD, name, ts[:], flags); err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	return nil
+}
+
+func utimeSpecs(atime, mtime time.Time) [2]unix.Timespec {
+	return [2]unix.Timespec{utimeSpec(atime), utimeSpec(mtime)}
+}
+
+func utimeSpec(t time.Time) unix.Timespec {
+	switch {
+	case t.IsZero():
+		return unix.Timespec{Nsec: utimeOmit}
+	case t.Equal(UtimeNow):
+		return unix.Timespec{Nsec: utimeNow}
+	}
+	return unix.NsecToTimespec(t.UnixNano())
+}
@@ -0,0 +1,10 @@
+//go:build aix || dragonfly || freebsd || linux || openbsd || solaris
+
+package pathlib
+
+import "golang.org/x/sys/unix"
+
+const (
+	utimeNow  = unix.UTIME_NOW
+	utimeOmit = unix.UTIME_OMIT
+)
@@ -0,0 +1,52 @@
+package pathlib
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Utimes implements [UtimesFilesystem].
+func (OS) Utimes(name string, atime, mtime time.Time, follow bool) error {
+	op, flags := "chtimes", uint32(syscall.FILE_FLAG_BACKUP_SEMANTICS) // allows opening directories
+	if !follow {
+		op, flags = "lutimes", flags|syscall.FILE_FLAG_OPEN_REPARSE_POINT
+	}
+	path, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	share := uint32(syscall.FILE_SHARE_READ | syscall.FILE_SHARE_WRITE | syscall.FILE_SHARE_DELETE)
+	h, err := syscall.CreateFile(path, syscall.FILE_WRITE_ATTRIBUTES, share, nil, syscall.OPEN_EXISTING, flags, 0)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	defer func() { _ = syscall.CloseHandle(h) }()
+	if err = setFileTime(h, atime, mtime); err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	return nil
+}
+
+// sets the times of an open file by handle.
+func futimes(f *os.File, atime, mtime time.Time) error {
+	err := control(f, func(fd uintptr) error { return setFileTime(syscall.Handle(fd), atime, mtime) })
+	if err != nil {
+		return &fs.PathError{Op: "SetFileTime", Path: f.Name(), Err: err}
+	}
+	return nil
+}
+
+func setFileTime(h syscall.Handle, atime, mtime time.Time) error {
+	return syscall.SetFileTime(h, nil, fileTime(atime), fileTime(mtime))
+}
+
+// converts t for SetFileTime, where nil leaves a time unchanged.
+func fileTime(t time.Time) *syscall.Filetime {
+	if t.IsZero() {
+		return nil
+	}
+	ft := syscall.NsecToFiletime(resolveUtime(t).UnixNano())
+	return &ft
+}
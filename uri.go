@@ -0,0 +1,196 @@
+package pathlib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// Returned when a URI's scheme isn't "file".
+type NotFileURI struct {
+	URI    string
+	Scheme string
+}
+
+func (n NotFileURI) Error() string {
+	return fmt.Sprintf("not a file URI: %q has scheme %q", n.URI, n.Scheme)
+}
+
+// Returned when a file URI can't be converted to a path, e.g. because it has a query,
+// is relative, or names a remote host that POSIX paths can't address.
+type InvalidFileURI struct {
+	URI    string
+	Reason string
+}
+
+func (i InvalidFileURI) Error() string {
+	return fmt.Sprintf("invalid file URI %q: %s", i.URI, i.Reason)
+}
+
+var errNotAbsolute = errors.New("path is not absolute")
+
+// Returns a file URI for the path, as described by RFC 8089. Relative paths are made
+// absolute first. On Windows, UNC paths like `\\host\share\dir` become
+// "file://host/share/dir" and drive paths like `C:\dir` become "file:///C:/dir".
+//
+// Bytes other than unreserved characters, sub-delimiters, ":", "@" and "/" are
+// percent-encoded, including non-ASCII ones.
+func (p PathStr) AsURI() (string, error) {
+	q, err := p.Abs()
+	if err != nil {
+		return "", err
+	}
+	return hostFlavor.uri(string(q))
+}
+
+// Returns a file URI for the absolute path. See [PathStr.AsURI].
+func (p PosixPath) AsURI() (string, error) {
+	return posixFlavor.uri(string(p))
+}
+
+// Returns a file URI for the absolute path. See [PathStr.AsURI].
+func (p WindowsPath) AsURI() (string, error) {
+	return windowsFlavor.uri(string(p))
+}
+
+// Returns the local path that a file URI refers to. The host must be empty or
+// "localhost", except on Windows, where other hosts become UNC paths. Fails with
+// [NotFileURI] or [InvalidFileURI].
+func FromURI(uri string) (PathStr, error) {
+	s, err := hostFlavor.fromURI(uri)
+	return PathStr(s), err
+}
+
+// Like [FromURI], but declares that the URI refers to a file.
+func FileFromURI(uri string) (File, error) {
+	s, err := hostFlavor.fromURI(uri)
+	return File(s), err
+}
+
+// Like [FromURI], but declares that the URI refers to a directory.
+func DirFromURI(uri string) (Dir, error) {
+	s, err := hostFlavor.fromURI(uri)
+	return Dir(s), err
+}
+
+// Like [FromURI], but for POSIX paths on any OS.
+func PosixPathFromURI(uri string) (PosixPath, error) {
+	s, err := posixFlavor.fromURI(uri)
+	return PosixPath(s), err
+}
+
+// Like [FromURI], but for Windows paths on any OS.
+func WindowsPathFromURI(uri string) (WindowsPath, error) {
+	s, err := windowsFlavor.fromURI(uri)
+	return WindowsPath(s), err
+}
+
+func (fl *flavor) uri(s string) (string, error) {
+	if !fl.isAbs(s) {
+		return "", &fs.PathError{Op: "asuri", Path: s, Err: errNotAbsolute}
+	}
+	s = fl.clean(s)
+	if !fl.windows {
+		return "file://" + escapeURIPath(s), nil
+	}
+	// \\?\UNC\host\share and \\?\C:\ only differ from their plain forms in skipping normalization
+	if fl.hasPrefixFold(s, `\\?\UNC`) {
+		s = `\\` + s[len(`\\?\UNC\`):]
+	} else if fl.hasPrefixFold(s, `\\?`) && len(s) >= 6 && isASCIILetter(s[4]) && s[5] == ':' {
+		s = s[len(`\\?\`):]
+	}
+	vol := fl.volumeName(s)
+	switch {
+	case len(vol) == 2:
+		return "file:///" + vol + escapeURIPath(fl.convert(s[2:], posixFlavor)), nil
+	case strings.HasPrefix(vol, `\\`) && !strings.HasPrefix(vol, `\\.\`) && !strings.HasPrefix(vol, `\\?\`):
+		host, path, _ := strings.Cut(s[2:], `\`)
+		return "file://" + escapeURIPath(host) + "/" + escapeURIPath(fl.convert(path, posixFlavor)), nil
+	}
+	return "", &fs.PathError{Op: "asuri", Path: s, Err: errors.ErrUnsupported}
+}
+
+// percent-encodes everything except RFC 3986's pchar and "/".
+func escapeURIPath(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~!$&'()*+,;=:@/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0xf])
+	}
+	return b.String()
+}
+
+func (fl *flavor) fromURI(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", InvalidFileURI{URI: uri, Reason: err.Error()}
+	}
+	invalid := func(reason string) (string, error) {
+		return "", InvalidFileURI{URI: uri, Reason: reason}
+	}
+	switch {
+	case u.Scheme != "file":
+		return "", NotFileURI{URI: uri, Scheme: u.Scheme}
+	case u.Opaque != "":
+		return invalid("path is relative")
+	case u.User != nil:
+		return invalid("has user information")
+	case u.RawQuery != "" || u.ForceQuery:
+		return invalid("has a query")
+	case u.Fragment != "":
+		return invalid("has a fragment")
+	case u.Port() != "":
+		return invalid("has a port")
+	}
+	// decode each segment separately so that an encoded separator can't split one
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, segment := range segments {
+		if segments[i], err = url.PathUnescape(segment); err != nil {
+			return invalid(err.Error())
+		}
+		if strings.IndexByte(segments[i], 0) >= 0 || fl.hasSeparator(segments[i]) {
+			return invalid("has an encoded separator or NUL byte")
+		}
+	}
+	path := strings.Join(segments, string(fl.separator))
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		host = ""
+	}
+	if !fl.windows {
+		if host != "" {
+			return invalid("names a remote host")
+		}
+		if !fl.isAbs(path) {
+			return invalid("has no path")
+		}
+		return path, nil
+	}
+	if host != "" {
+		return `\\` + host + path, nil
+	}
+	// \C:\dir or \C|\dir, as in file:///C:/dir
+	if len(path) >= 3 && path[0] == '\\' && (path[2] == ':' || path[2] == '|') && isASCIILetter(path[1]) {
+		path = path[1:2] + ":" + path[3:]
+		if len(path) == 2 {
+			path += `\`
+		}
+	}
+	if !fl.isAbs(path) {
+		return invalid("path is relative")
+	}
+	return path, nil
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
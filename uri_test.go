@@ -0,0 +1,148 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExamplePosixPath_AsURI() {
+	fmt.Println(expect(pathlib.PosixPath("/home/zoë/my notes#1.txt").AsURI()))
+	fmt.Println(expect(pathlib.PosixPathFromURI("file:///home/zo%C3%AB/my%20notes%231.txt")))
+	// Output:
+	// file:///home/zo%C3%AB/my%20notes%231.txt
+	// /home/zoë/my notes#1.txt
+}
+
+func ExampleWindowsPath_AsURI() {
+	fmt.Println(expect(pathlib.WindowsPath(`C:\Program Files\app`).AsURI()))
+	fmt.Println(expect(pathlib.WindowsPath(`\\server\share\dir`).AsURI()))
+	fmt.Println(expect(pathlib.WindowsPathFromURI("file://server/share/dir")))
+	// Output:
+	// file:///C:/Program%20Files/app
+	// file://server/share/dir
+	// \\server\share\dir
+}
+
+func TestPosixPath_AsURI(t *testing.T) {
+	cases := map[pathlib.PosixPath]string{
+		"/":               "file:///",
+		"/a/b":            "file:///a/b",
+		"//a/./b/../c":    "file:///a/c",
+		"/a?b%c":          "file:///a%3Fb%25c",
+		"/a:b@c,d;e=f+g~": "file:///a:b@c,d;e=f+g~",
+		"/\x7f[\\]":       "file:///%7F%5B%5C%5D",
+		"/日本":             "file:///%E6%97%A5%E6%9C%AC",
+	}
+	for p, expected := range cases {
+		if actual := expect(p.AsURI()); actual != expected {
+			t.Errorf("%q.AsURI(): expected %q, got %q", p, expected, actual)
+		}
+		if back := expect(pathlib.PosixPathFromURI(expected)); back != p.Clean() {
+			t.Errorf("PosixPathFromURI(%q): expected %q, got %q", expected, p.Clean(), back)
+		}
+	}
+	if _, err := pathlib.PosixPath("a/b").AsURI(); err == nil {
+		t.Error("expected an error for a relative path")
+	}
+}
+
+func TestWindowsPath_AsURI(t *testing.T) {
+	cases := map[pathlib.WindowsPath]string{
+		`C:\`:                   "file:///C:/",
+		`c:\a\..\b c`:           "file:///c:/b%20c",
+		`\\host\share`:          "file://host/share",
+		`\\host\share\a\b`:      "file://host/share/a/b",
+		`\\?\C:\a`:              "file:///C:/a",
+		`\\?\UNC\host\share\a`:  "file://host/share/a",
+		`C:\Users\Zoë\100%.txt`: "file:///C:/Users/Zo%C3%AB/100%25.txt",
+	}
+	for p, expected := range cases {
+		if actual := expect(p.AsURI()); actual != expected {
+			t.Errorf("%q.AsURI(): expected %q, got %q", p, expected, actual)
+		}
+	}
+	for _, p := range []pathlib.WindowsPath{`a\b`, `C:a`, `\a`, `\\.\pipe\x`} {
+		if _, err := p.AsURI(); err == nil {
+			t.Errorf("%q.AsURI(): expected an error", p)
+		}
+	}
+}
+
+func TestFromURI(t *testing.T) {
+	posix := map[string]pathlib.PosixPath{
+		"file:///a/b":          "/a/b",
+		"file:/a/b":            "/a/b",
+		"FILE://localhost/a/b": "/a/b",
+		"file:///a%2Bb/%7Ec":   "/a+b/~c",
+	}
+	for uri, expected := range posix {
+		if actual := expect(pathlib.PosixPathFromURI(uri)); actual != expected {
+			t.Errorf("PosixPathFromURI(%q): expected %q, got %q", uri, expected, actual)
+		}
+	}
+	windows := map[string]pathlib.WindowsPath{
+		"file:///C:/a/b":        `C:\a\b`,
+		"file:///c|/a":          `c:\a`,
+		"file:///C:":            `C:\`,
+		"file://host/share/a":   `\\host\share\a`,
+		"file:////host/share/a": `\\host\share\a`,
+		"file://localhost/C:/a": `C:\a`,
+	}
+	for uri, expected := range windows {
+		if actual := expect(pathlib.WindowsPathFromURI(uri)); actual != expected {
+			t.Errorf("WindowsPathFromURI(%q): expected %q, got %q", uri, expected, actual)
+		}
+	}
+
+	invalid := []string{
+		"file:a/b", "file://user@/a", "file:///a?b", "file:///a#b", "file://:80/a",
+		"file:///a%2Fb", "file:///a%00b", "file:///a%zz", "file://", "file://localhost",
+	}
+	for _, uri := range invalid {
+		var target pathlib.InvalidFileURI
+		if _, err := pathlib.PosixPathFromURI(uri); !errors.As(err, &target) {
+			t.Errorf("PosixPathFromURI(%q): expected InvalidFileURI, got %v", uri, err)
+		}
+	}
+	if _, err := pathlib.PosixPathFromURI("file://host/a"); err == nil {
+		t.Error("expected an error for a remote host on POSIX")
+	}
+	if _, err := pathlib.WindowsPathFromURI("file:///C:/a%5Cb"); err == nil {
+		t.Error("expected an error for an encoded backslash on Windows")
+	}
+	for _, uri := range []string{"file:///a", "file:///"} {
+		if _, err := pathlib.WindowsPathFromURI(uri); err == nil {
+			t.Errorf("WindowsPathFromURI(%q): expected an error for a path without a drive", uri)
+		}
+	}
+
+	var notFile pathlib.NotFileURI
+	_, err := pathlib.FromURI("https://example.com/a")
+	if !errors.As(err, &notFile) || notFile.Scheme != "https" {
+		t.Errorf("expected NotFileURI, got %v", err)
+	}
+}
+
+func TestPathStr_AsURI(t *testing.T) {
+	dir := pathlib.Dir(t.TempDir())
+	file := dir.Join("a b", "ü.txt")
+	uri := expect(file.AsURI())
+	if runtime.GOOS != "windows" && uri != "file://"+string(dir)+"/a%20b/%C3%BC.txt" {
+		t.Errorf("unexpected URI %q", uri)
+	}
+	if back := expect(pathlib.FileFromURI(uri)); !back.Eq(pathlib.File(file)) {
+		t.Errorf("FileFromURI(%q): expected %q, got %q", uri, file, back)
+	}
+	if back := expect(pathlib.DirFromURI(expect(dir.Join(".").AsURI()))); !back.Eq(dir) {
+		t.Errorf("expected %q, got %q", dir, back)
+	}
+
+	wd := expect(pathlib.Cwd())
+	if rel := expect(pathlib.PathStr("x").AsURI()); rel != expect(wd.Join("x").AsURI()) {
+		t.Errorf("expected a relative path to resolve against %q, got %q", wd, rel)
+	}
+}
@@ -0,0 +1,174 @@
+package pathlib
+
+import (
+	"io/fs"
+	"iter"
+	"os"
+)
+
+// The order in which [Dir.WalkSeq] yields a directory relative to its contents.
+type WalkOrder int
+
+const (
+	// Yield each directory before its contents.
+	PreOrder WalkOrder = iota
+	// Yield each directory after its contents, like `find -depth`.
+	PostOrder
+)
+
+// Options that control how [Dir.WalkSeq] traverses a directory tree.
+type WalkOptions struct {
+	Order WalkOrder
+	// The maximum depth to descend to, where the walked directory has depth 0.
+	// Zero or less means there is no limit.
+	MaxDepth int
+	// Descend into symlinks that point to directories, like `find -L`. Links that
+	// lead back to a directory being walked are reported with a [syscall.ELOOP] error.
+	FollowSymlinks bool
+	// If non-nil, Follow decides which symlinks are descended into instead of
+	// FollowSymlinks. It is only called for symlinks, before they are followed.
+	Follow func(entry Entry) bool
+	// Don't descend into directories on other devices, like `find -xdev`.
+	SameDevice bool
+	// If non-nil, entries for which Skip returns true are not yielded, and skipped
+	// directories are not descended into.
+	Skip func(entry Entry) bool
+	// If non-nil, directories for which Prune returns true are yielded but not
+	// descended into, like `find -prune`.
+	Prune func(entry Entry) bool
+}
+
+// Lazily walk the directory tree rooted at d, yielding an [Entry] for every path
+// in lexical order within each directory. Unlike [Dir.Walk], the root is followed
+// if it is a symlink, so its entry describes the symlink's target. Errors reading a
+// directory are yielded alongside the directory's entry, and the walk continues.
+//
+// Breaking out of the loop stops the walk.
+func (d Dir) WalkSeq(opts WalkOptions) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		root := PathStr(d)
+		// use the backend's own infos, since os.SameFile can't see through onDisk
+		info, err := filesystemOf(string(root)).Stat(string(root))
+		if err != nil {
+			yield(Entry{path: root}, err)
+			return
+		}
+		w := walker{WalkOptions: opts, yield: yield}
+		w.rootDev, w.hasDev = deviceOf(info)
+		var dirInfo fs.FileInfo
+		if info.IsDir() {
+			dirInfo = info
+		}
+		w.visit(root, fs.FileInfoToDirEntry(info), dirInfo, 0, nil)
+	}
+}
+
+type walker struct {
+	WalkOptions
+	yield   func(Entry, error) bool
+	rootDev uint64
+	hasDev  bool
+}
+
+// Yields p and, if dirInfo is non-nil, its descendants. Returns false once the
+// consumer stops iterating.
+func (w *walker) visit(p PathStr, dirEntry fs.DirEntry, dirInfo fs.FileInfo, depth int, ancestors []fs.FileInfo) bool {
+	entry := NewEntry(p, dirEntry)
+	if w.Skip != nil && w.Skip(entry) {
+		return true
+	}
+	descend := dirInfo != nil && (w.MaxDepth <= 0 || depth < w.MaxDepth)
+	if descend && w.Prune != nil {
+		descend = !w.Prune(entry)
+	}
+	if descend && w.SameDevice && w.hasDev {
+		dev, ok := deviceOf(dirInfo)
+		descend = !ok || dev == w.rootDev
+	}
+	if w.Order == PreOrder && !w.yield(entry, nil) {
+		return false
+	}
+	if descend && !w.descend(entry, dirInfo, depth, ancestors) {
+		return false
+	}
+	return w.Order != PostOrder || w.yield(entry, nil)
+}
+
+func (w *walker) descend(dirEntry Entry, dirInfo fs.FileInfo, depth int, ancestors []fs.FileInfo) bool {
+	dir := dirEntry.Path()
+	entries, err := readDir(dir)
+	if err != nil && !w.yield(dirEntry, err) {
+		return false
+	}
+	ancestors = append(ancestors, dirInfo)
+	for _, entry := range entries {
+		child := dir.Join(entry.Name())
+		var childInfo fs.FileInfo
+		switch {
+		case entry.IsDir():
+			// lstat through the child's own backend so that bound paths report their own device
+			if childInfo, err = filesystemOf(string(child)).Lstat(string(child)); err != nil {
+				if !w.yield(NewEntry(child, entry), err) {
+					return false
+				}
+				continue
+			}
+		case entry.Type()&fs.ModeSymlink != 0 && w.follows(child, entry):
+			// dangling links are yielded, but not descended into
+			if info, err := filesystemOf(string(child)).Stat(string(child)); err == nil && info.IsDir() {
+				if containsSameFile(ancestors, info) {
+					loop := &fs.PathError{Op: "walk", Path: string(child), Err: errLoop}
+					if !w.yield(NewEntry(child, entry), loop) {
+						return false
+					}
+					continue
+				}
+				childInfo = info
+			}
+		}
+		if !w.visit(child, entry, childInfo, depth+1, ancestors) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns true if the symlink should be descended into if it leads to a directory.
+func (w *walker) follows(link PathStr, entry fs.DirEntry) bool {
+	if w.Follow != nil {
+		return w.Follow(NewEntry(link, entry))
+	}
+	return w.FollowSymlinks
+}
+
+func containsSameFile(infos []fs.FileInfo, info fs.FileInfo) bool {
+	for _, other := range infos {
+		if sameFile(other, info) {
+			return true
+		}
+	}
+	return false
+}
+
+// Like [os.SameFile], but also understands [Info], statx(2) and [MemFS] infos.
+func sameFile(a, b fs.FileInfo) bool {
+	type wrapper interface{ unwrap() fs.FileInfo }
+	if w, ok := a.(wrapper); ok {
+		a = w.unwrap()
+	}
+	if w, ok := b.(wrapper); ok {
+		b = w.unwrap()
+	}
+	_, aIsMem := a.Sys().(*MemStat)
+	_, bIsMem := b.Sys().(*MemStat)
+	if aIsMem != bIsMem {
+		// MemFS device numbers may collide with real ones
+		return false
+	}
+	aDev, aIno, aOK := inodeOf(a)
+	bDev, bIno, bOK := inodeOf(b)
+	if aOK && bOK {
+		return aDev == bDev && aIno == bIno
+	}
+	return os.SameFile(a, b)
+}
@@ -0,0 +1,192 @@
+package pathlib_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleDir_WalkSeq() {
+	dir := expect(pathlib.TempDir().Join("dir-walk-seq-example").AsDir().Make(0755))
+	defer func() { _, _ = dir.RemoveAll() }()
+
+	_ = expect(dir.Join("foo/bar/baz").AsDir().MakeAll(0755, 0755))
+	_ = expect(dir.Join("a/b/c").AsDir().MakeAll(0755, 0755))
+
+	for entry, err := range dir.WalkSeq(pathlib.WalkOptions{Order: pathlib.PostOrder}) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(expect(entry.Path().Rel(dir)))
+		if entry.Name() == "foo" {
+			break
+		}
+	}
+
+	// output:
+	// a/b/c
+	// a/b
+	// a
+	// foo/bar/baz
+	// foo/bar
+	// foo
+}
+
+// collects the paths yielded by WalkSeq relative to the walked directory.
+func walked(t *testing.T, dir pathlib.Dir, opts pathlib.WalkOptions) (paths []string, errs []error) {
+	t.Helper()
+	for entry, err := range dir.WalkSeq(opts) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		paths = append(paths, expect(entry.Path().Rel(dir)).String())
+	}
+	return
+}
+
+func TestDir_WalkSeq(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		expect(temp.Join("a/b/c").AsDir().MakeAll(0o755, 0o755))
+		expect(temp.Join(".git/objects").AsDir().MakeAll(0o755, 0o755))
+		enforce(expect(temp.Join("a/file.txt").AsFile().Make(0o644)).Close())
+
+		cases := []struct {
+			name     string
+			opts     pathlib.WalkOptions
+			expected []string
+		}{
+			{"pre-order", pathlib.WalkOptions{}, []string{".", ".git", ".git/objects", "a", "a/b", "a/b/c", "a/file.txt"}},
+			{"post-order", pathlib.WalkOptions{Order: pathlib.PostOrder}, []string{".git/objects", ".git", "a/b/c", "a/b", "a/file.txt", "a", "."}},
+			{"max depth", pathlib.WalkOptions{MaxDepth: 1}, []string{".", ".git", "a"}},
+			{
+				"skip",
+				pathlib.WalkOptions{Skip: func(entry pathlib.Entry) bool {
+					return entry.Name() == ".git" || !entry.IsDir()
+				}},
+				[]string{".", "a", "a/b", "a/b/c"},
+			},
+			{
+				"prune",
+				pathlib.WalkOptions{Prune: func(entry pathlib.Entry) bool {
+					return entry.Name() == ".git" || entry.Name() == "b"
+				}},
+				[]string{".", ".git", "a", "a/b", "a/file.txt"},
+			},
+			{
+				"prune post-order",
+				pathlib.WalkOptions{Order: pathlib.PostOrder, Prune: func(entry pathlib.Entry) bool {
+					return entry.Name() == "a"
+				}},
+				[]string{".git/objects", ".git", "a", "."},
+			},
+		}
+		for _, c := range cases {
+			paths, errs := walked(t, temp, c.opts)
+			if len(errs) > 0 {
+				t.Errorf("%s: unexpected errors %v", c.name, errs)
+			}
+			if !slices.Equal(paths, c.expected) {
+				t.Errorf("%s:\nexpected %q\ngot      %q", c.name, c.expected, paths)
+			}
+		}
+	})
+}
+
+func TestDir_WalkSeq_symlinks(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		target := expect(temp.Join("target/sub").AsDir().MakeAll(0o755, 0o755))
+		walkRoot := expect(temp.Join("root").AsDir().Make(0o755))
+		expect(walkRoot.Join("link").AsSymlink().LinkTo(pathlib.PathStr(target.Parent())))
+		expect(target.Join("loop").AsSymlink().LinkTo(pathlib.PathStr(target.Parent())))
+		expect(walkRoot.Join("dangling").AsSymlink().LinkTo("nowhere"))
+
+		paths, errs := walked(t, walkRoot, pathlib.WalkOptions{})
+		if expected := []string{".", "dangling", "link"}; !slices.Equal(paths, expected) {
+			t.Errorf("without following:\nexpected %q\ngot      %q", expected, paths)
+		}
+		if len(errs) > 0 {
+			t.Errorf("unexpected errors %v", errs)
+		}
+
+		paths, errs = walked(t, walkRoot, pathlib.WalkOptions{FollowSymlinks: true})
+		if expected := []string{".", "dangling", "link", "link/sub"}; !slices.Equal(paths, expected) {
+			t.Errorf("following:\nexpected %q\ngot      %q", expected, paths)
+		}
+		if len(errs) != 1 || !errors.Is(errs[0], errLoop) {
+			t.Errorf("expected one ELOOP error, got %v", errs)
+		}
+	})
+}
+
+func TestDir_WalkSeq_notDir(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		file := temp.Join("file.txt")
+		enforce(expect(file.AsFile().Make(0o644)).Close())
+		paths, errs := walked(t, file.AsDir(), pathlib.WalkOptions{})
+		if !slices.Equal(paths, []string{"."}) || len(errs) > 0 {
+			t.Errorf("expected only the file itself, got %q %v", paths, errs)
+		}
+
+		paths, errs = walked(t, temp.Join("missing").AsDir(), pathlib.WalkOptions{})
+		if len(paths) > 0 || len(errs) != 1 || !errors.Is(errs[0], fs.ErrNotExist) {
+			t.Errorf("expected a single fs.ErrNotExist, got %q %v", paths, errs)
+		}
+	})
+}
+
+func TestDir_WalkSeq_sameDevice(t *testing.T) {
+	temp := pathlib.Dir(t.TempDir())
+	mountPoint := expect(temp.Join("mnt").AsDir().Make(0o755))
+	mem := pathlib.NewMemFS()
+	enforce(mem.MkdirAll(mountPoint.Join("inside").String(), 0o755))
+	defer pathlib.Bind(mountPoint, mem)()
+
+	paths, errs := walked(t, temp, pathlib.WalkOptions{SameDevice: true})
+	if expected := []string{".", "mnt"}; !slices.Equal(paths, expected) {
+		t.Errorf("expected %q\ngot      %q", expected, paths)
+	}
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors %v", errs)
+	}
+	paths, _ = walked(t, temp, pathlib.WalkOptions{})
+	if expected := []string{".", "mnt", "mnt/inside"}; !slices.Equal(paths, expected) {
+		t.Errorf("expected %q\ngot      %q", expected, paths)
+	}
+}
+
+func TestDir_WalkSeq_entries(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		writeFile(t, temp.Join("dir/file").AsFile(), "content")
+		expect(temp.Join("link").AsSymlink().LinkTo("dir"))
+
+		kinds := map[string]pathlib.EntryKind{}
+		for entry, err := range temp.WalkSeq(pathlib.WalkOptions{}) {
+			enforce(err)
+			kinds[expect(entry.Path().Rel(temp)).String()] = entry.Kind()
+			if entry.Kind() == pathlib.FileKind && expect(entry.Lstat()).Size() != 7 {
+				t.Errorf("unexpected size for %q", entry.Path())
+			}
+		}
+		expected := map[string]pathlib.EntryKind{
+			".":        pathlib.DirKind,
+			"dir":      pathlib.DirKind,
+			"dir/file": pathlib.FileKind,
+			"link":     pathlib.SymlinkKind,
+		}
+		if !maps.Equal(kinds, expected) {
+			t.Errorf("expected %v, got %v", expected, kinds)
+		}
+
+		for entry, err := range temp.Join("missing").AsDir().WalkSeq(pathlib.WalkOptions{}) {
+			if !errors.Is(err, fs.ErrNotExist) || entry.Path() != temp.Join("missing") {
+				t.Errorf("expected fs.ErrNotExist for the missing root, got %q %v", entry.Path(), err)
+			}
+		}
+	})
+}
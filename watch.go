@@ -0,0 +1,318 @@
+package pathlib
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// A set of changes reported by [Dir.Watch] and [File.Watch].
+type EventOp uint32
+
+const (
+	// A path was created, or another path was renamed to it.
+	Create EventOp = 1 << iota
+	// A file's contents changed.
+	Write
+	// A path was removed.
+	Remove
+	// A path was renamed to something else. The new path gets a Create event if it is
+	// being watched.
+	Rename
+	// A path's permissions or other metadata changed.
+	Chmod
+)
+
+// Returns true if op includes every change in other.
+func (op EventOp) Has(other EventOp) bool {
+	return op&other == other
+}
+
+// Returns the names of the changes joined by "|", e.g. "CREATE|WRITE".
+func (op EventOp) String() string {
+	var names []string
+	for i, name := range []string{"CREATE", "WRITE", "REMOVE", "RENAME", "CHMOD"} {
+		if op.Has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// A change to a watched path.
+type Event struct {
+	Path PathStr
+	Op   EventOp
+	// Whether the path is a directory.
+	IsDir bool
+	// Whether the path is a symlink, as seen by lstat(2). Always false if the path was
+	// removed before it could be observed.
+	IsSymlink bool
+}
+
+// Returns the event's path as a [Dir] if it is a directory, a [Symlink] if it is a
+// symlink, or else as a [File].
+func (e Event) Typed() PurePath {
+	switch {
+	case e.IsDir:
+		return Dir(e.Path)
+	case e.IsSymlink:
+		return Symlink(e.Path)
+	}
+	return File(e.Path)
+}
+
+func (e Event) String() string {
+	return e.Op.String() + " " + string(e.Path)
+}
+
+// Returned when events were dropped because they arrived faster than they were read.
+var ErrEventOverflow = errors.New("pathlib: too many filesystem events")
+
+// Options that control [Dir.Watch].
+type WatchOptions struct {
+	// Also watch subdirectories, including ones created while watching.
+	Recursive bool
+	// If positive, hold events until no new ones have arrived for this long, then
+	// deliver them with each path's changes merged into a single event.
+	Debounce time.Duration
+	// Compare snapshots from [Dir.Stat] instead of using the platform's change
+	// notifications. Polling is always used for paths bound to backends other than
+	// [OS] and on platforms other than Linux. Polling can't see changes that are
+	// undone between polls.
+	Poll bool
+	// How often to take snapshots when polling. Defaults to half a second.
+	PollInterval time.Duration
+}
+
+// Lazily yield changes to the directory's entries, or to its whole tree if
+// [WatchOptions.Recursive] is set. On Linux, this uses inotify(7).
+//
+// Iteration ends when ctx is done, when the loop exits, or after d itself is removed
+// or renamed.
+func (d Dir) Watch(ctx context.Context, opts WatchOptions) iter.Seq2[Event, error] {
+	return watch(ctx, d, opts, nil)
+}
+
+// Lazily yield changes to the file. The parent directory is watched, so replacing the
+// file by renaming another file over it is reported as a [Create].
+//
+// Iteration ends when ctx is done, when the loop exits, or after the parent directory
+// is removed or renamed.
+func (f File) Watch(ctx context.Context) iter.Seq2[Event, error] {
+	target := PathStr(f).Clean()
+	return watch(ctx, f.Parent(), WatchOptions{}, func(p PathStr) bool { return p == target })
+}
+
+// an event or an error from a source of events.
+type watchItem struct {
+	event Event
+	err   error
+}
+
+func watch(ctx context.Context, d Dir, opts WatchOptions, match func(PathStr) bool) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		items := make(chan watchItem)
+		err := errors.ErrUnsupported
+		if _, isOS := filesystemOf(string(d)).(OS); isOS && !opts.Poll {
+			err = watchNotify(ctx, d, opts.Recursive, items)
+		}
+		if errors.Is(err, errors.ErrUnsupported) {
+			err = watchPoll(ctx, d, opts, items)
+		}
+		if err != nil {
+			yield(Event{}, err)
+			return
+		}
+		deliver(ctx, items, opts.Debounce, match, yield)
+	}
+}
+
+// yields items as they arrive, or in debounced batches.
+func deliver(
+	ctx context.Context,
+	items <-chan watchItem,
+	debounce time.Duration,
+	match func(PathStr) bool,
+	yield func(Event, error) bool,
+) {
+	var pending []Event
+	var timer <-chan time.Time
+	flush := func() bool {
+		for _, e := range pending {
+			if !yield(e, nil) {
+				return false
+			}
+		}
+		pending, timer = pending[:0], nil
+		return true
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer:
+			if !flush() {
+				return
+			}
+		case item, ok := <-items:
+			if !ok {
+				flush()
+				return
+			}
+			if item.err != nil {
+				if !flush() || !yield(Event{}, item.err) {
+					return
+				}
+				continue
+			}
+			if match != nil && !match(item.event.Path) {
+				continue
+			}
+			if debounce <= 0 {
+				if !yield(item.event, nil) {
+					return
+				}
+				continue
+			}
+			i := slices.IndexFunc(pending, func(e Event) bool { return e.Path == item.event.Path })
+			if i < 0 {
+				pending = append(pending, item.event)
+			} else {
+				pending[i].Op |= item.event.Op
+				pending[i].IsDir = item.event.IsDir
+				pending[i].IsSymlink = item.event.IsSymlink
+			}
+			timer = time.After(debounce)
+		}
+	}
+}
+
+// sends an item unless ctx is done first.
+func send(ctx context.Context, items chan<- watchItem, item watchItem) bool {
+	select {
+	case items <- item:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Polling ---------------------------------------------------------------------
+
+func watchPoll(ctx context.Context, d Dir, opts WatchOptions, items chan<- watchItem) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	walkOpts := WalkOptions{MaxDepth: 1}
+	if opts.Recursive {
+		walkOpts.MaxDepth = 0
+	}
+	before, err := snapshot(d, walkOpts)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(items)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			after, err := snapshot(d, walkOpts)
+			if errors.Is(err, fs.ErrNotExist) {
+				send(ctx, items, watchItem{event: Event{Path: PathStr(d), Op: Remove, IsDir: true}})
+				return
+			} else if err != nil {
+				if !send(ctx, items, watchItem{err: err}) {
+					return
+				}
+				continue
+			}
+			for _, e := range diffSnapshots(before, after) {
+				if !send(ctx, items, watchItem{event: e}) {
+					return
+				}
+			}
+			before = after
+		}
+	}()
+	return nil
+}
+
+// observes every path beneath d, excluding d itself.
+func snapshot(d Dir, opts WalkOptions) (map[PathStr]fs.FileInfo, error) {
+	if _, err := filesystemOf(string(d)).Stat(string(d)); err != nil {
+		return nil, err
+	}
+	result := map[PathStr]fs.FileInfo{}
+	for entry, err := range d.WalkSeq(opts) {
+		p := entry.Path()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // removed mid-walk
+		} else if err != nil {
+			return nil, err
+		}
+		if p == PathStr(d) {
+			continue
+		}
+		info, err := filesystemOf(string(p)).Lstat(string(p))
+		if err == nil {
+			result[p] = info
+		}
+	}
+	return result, nil
+}
+
+// describes how one snapshot became another, in lexical order of path.
+func diffSnapshots(before, after map[PathStr]fs.FileInfo) (events []Event) {
+	for p, old := range before {
+		current, ok := after[p]
+		switch {
+		case !ok:
+			op := Remove
+			for _, info := range after {
+				if sameFile(old, info) {
+					op = Rename
+					break
+				}
+			}
+			events = append(events, eventOf(p, op, old))
+		case !sameFile(old, current):
+			events = append(events, eventOf(p, Create, current))
+		default:
+			var op EventOp
+			if !current.IsDir() && (!old.ModTime().Equal(current.ModTime()) || old.Size() != current.Size()) {
+				op |= Write
+			}
+			if old.Mode() != current.Mode() {
+				op |= Chmod
+			}
+			if op != 0 {
+				events = append(events, eventOf(p, op, current))
+			}
+		}
+	}
+	for p, info := range after {
+		if _, ok := before[p]; !ok {
+			events = append(events, eventOf(p, Create, info))
+		}
+	}
+	slices.SortFunc(events, func(a, b Event) int { return strings.Compare(string(a.Path), string(b.Path)) })
+	return
+}
+
+// describes a change to a path observed with lstat.
+func eventOf(p PathStr, op EventOp, info fs.FileInfo) Event {
+	return Event{Path: p, Op: op, IsDir: info.IsDir(), IsSymlink: info.Mode()&fs.ModeSymlink != 0}
+}
@@ -0,0 +1,195 @@
+package pathlib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_DELETE | unix.IN_MOVED_FROM |
+	unix.IN_MOVED_TO | unix.IN_ATTRIB | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF | unix.IN_ONLYDIR
+
+type inotify struct {
+	file      *os.File
+	root      PathStr
+	recursive bool
+	dirs      map[int32]PathStr // watch descriptors
+}
+
+func watchNotify(ctx context.Context, d Dir, recursive bool, items chan<- watchItem) error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return &fs.PathError{Op: "inotify_init1", Path: string(d), Err: err}
+	}
+	// a non-blocking descriptor uses the runtime's poller, so Close interrupts Read
+	w := &inotify{
+		file:      os.NewFile(uintptr(fd), "inotify"),
+		root:      PathStr(d).Clean(),
+		recursive: recursive,
+		dirs:      map[int32]PathStr{},
+	}
+	if err = w.add(w.root); err == nil && recursive {
+		err = w.addTree(w.root, nil)
+	}
+	if err != nil {
+		_ = w.file.Close()
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = w.file.Close()
+	}()
+	go w.read(ctx, items)
+	return nil
+}
+
+// watches a directory.
+func (w *inotify) add(dir PathStr) error {
+	return control(w.file, func(fd uintptr) error {
+		wd, err := unix.InotifyAddWatch(int(fd), string(dir), inotifyMask)
+		if err != nil {
+			return &fs.PathError{Op: "inotify_add_watch", Path: string(dir), Err: err}
+		}
+		w.dirs[int32(wd)] = dir
+		return nil
+	})
+}
+
+// watches the directories beneath dir. If created is non-nil, it is called for every
+// path found, since they may have been created before the watches were added.
+func (w *inotify) addTree(dir PathStr, created func(Event) bool) error {
+	for entry, err := range Dir(dir).WalkSeq(WalkOptions{}) {
+		p := entry.Path()
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, unix.ENOTDIR) {
+			continue // removed or replaced mid-walk
+		} else if err != nil {
+			return err
+		}
+		if p == dir {
+			continue
+		}
+		info, err := lstat(p)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			if err = w.add(p); errors.Is(err, fs.ErrNotExist) || errors.Is(err, unix.ENOTDIR) {
+				continue
+			} else if err != nil {
+				return err
+			}
+		}
+		if created != nil && !created(eventOf(p, Create, info)) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// stops watching the directory and everything beneath it.
+func (w *inotify) remove(dir PathStr) {
+	for wd, p := range w.dirs {
+		if p == dir || strings.HasPrefix(string(p), string(dir)+string(os.PathSeparator)) {
+			_ = control(w.file, func(fd uintptr) error {
+				_, err := unix.InotifyRmWatch(int(fd), uint32(wd))
+				return err
+			})
+			delete(w.dirs, wd)
+		}
+	}
+}
+
+func (w *inotify) read(ctx context.Context, items chan<- watchItem) {
+	defer close(items)
+	emit := func(e Event) bool { return send(ctx, items, watchItem{event: e}) }
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				send(ctx, items, watchItem{err: err})
+			}
+			return
+		}
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(raw.Len)]
+			offset += unix.SizeofInotifyEvent + int(raw.Len)
+			name := string(bytes.TrimRight(nameBytes, "\x00"))
+			if raw.Mask&unix.IN_Q_OVERFLOW != 0 {
+				if !send(ctx, items, watchItem{err: ErrEventOverflow}) {
+					return
+				}
+				continue
+			}
+			dir, ok := w.dirs[raw.Wd]
+			if !ok {
+				continue // from a removed watch
+			}
+			if raw.Mask&unix.IN_IGNORED != 0 {
+				delete(w.dirs, raw.Wd)
+				if dir == w.root {
+					return
+				}
+				continue
+			}
+			e := Event{Path: dir, IsDir: raw.Mask&unix.IN_ISDIR != 0}
+			if name != "" {
+				e.Path = dir.Join(name)
+			}
+			switch {
+			case raw.Mask&unix.IN_CREATE != 0, raw.Mask&unix.IN_MOVED_TO != 0:
+				e.Op = Create
+			case raw.Mask&unix.IN_MODIFY != 0:
+				e.Op = Write
+			case raw.Mask&unix.IN_DELETE != 0:
+				e.Op = Remove
+			case raw.Mask&unix.IN_MOVED_FROM != 0:
+				e.Op = Rename
+			case raw.Mask&unix.IN_ATTRIB != 0:
+				e.Op = Chmod
+			case raw.Mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0:
+				if dir != w.root {
+					continue // already reported by the parent's watch
+				}
+				e.IsDir, e.Op = true, Remove
+				if raw.Mask&unix.IN_MOVE_SELF != 0 {
+					e.Op = Rename
+				}
+				emit(e)
+				return
+			default:
+				continue
+			}
+			if !e.IsDir && e.Op&(Create|Write|Chmod) != 0 {
+				// inotify doesn't report symlinks as such
+				if info, err := lstat(e.Path); err == nil {
+					e.IsSymlink = info.Mode()&fs.ModeSymlink != 0
+				}
+			}
+			if !emit(e) {
+				return
+			}
+			if !e.IsDir || !w.recursive {
+				continue
+			}
+			switch e.Op {
+			case Create:
+				if err := w.add(e.Path); err == nil {
+					err = w.addTree(e.Path, emit)
+				}
+				if err != nil && !errors.Is(err, fs.ErrNotExist) && !send(ctx, items, watchItem{err: err}) {
+					return
+				}
+			case Rename:
+				w.remove(e.Path)
+			}
+		}
+	}
+}
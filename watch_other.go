@@ -0,0 +1,13 @@
+//go:build !linux
+
+package pathlib
+
+import (
+	"context"
+	"errors"
+)
+
+// Only Linux's inotify(7) is supported; other platforms poll.
+func watchNotify(context.Context, Dir, bool, chan<- watchItem) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,227 @@
+package pathlib_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skalt/pathlib.go"
+)
+
+func ExampleEventOp_String() {
+	fmt.Println(pathlib.Create | pathlib.Write)
+	fmt.Println(pathlib.Event{Path: "config.toml", Op: pathlib.Chmod})
+	// Output:
+	// CREATE|WRITE
+	// CHMOD config.toml
+}
+
+// Watches in the background, collecting events until one matches done or the test
+// times out. Call the returned function after making changes.
+func watching(
+	t *testing.T,
+	seq func(ctx context.Context) func(yield func(pathlib.Event, error) bool),
+	done func(pathlib.Event) bool,
+) (wait func() []pathlib.Event) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	events := make(chan []pathlib.Event, 1)
+	ready := make(chan struct{})
+	go func() {
+		var result []pathlib.Event
+		defer func() { events <- result }()
+		close(ready)
+		for e, err := range seq(ctx) {
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			result = append(result, e)
+			if done(e) {
+				return
+			}
+		}
+	}()
+	<-ready
+	time.Sleep(50 * time.Millisecond) // let the watch start
+	return func() []pathlib.Event {
+		t.Helper()
+		result := <-events
+		if ctx.Err() != nil {
+			t.Fatalf("timed out, got %v", result)
+		}
+		return result
+	}
+}
+
+// returns the ops reported for a path, merged.
+func opsFor(events []pathlib.Event, p pathlib.PathStr) (ops pathlib.EventOp) {
+	for _, e := range events {
+		if e.Path == p {
+			ops |= e.Op
+		}
+	}
+	return
+}
+
+// runs the test with inotify where available, and with polling on each backend.
+func eachWatcher(t *testing.T, test func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions)) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		test(t, temp, pathlib.WatchOptions{PollInterval: 20 * time.Millisecond})
+	})
+	t.Run("poll", func(t *testing.T) {
+		test(t, pathlib.Dir(t.TempDir()), pathlib.WatchOptions{Poll: true, PollInterval: 20 * time.Millisecond})
+	})
+}
+
+func TestDir_Watch(t *testing.T) {
+	eachWatcher(t, func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions) {
+		existing := expect(temp.Join("existing.txt").AsFile().WriteString("", 0o644))
+		stop := temp.Join("stop")
+		wait := watching(t, func(ctx context.Context) func(func(pathlib.Event, error) bool) {
+			return temp.Watch(ctx, opts)
+		}, func(e pathlib.Event) bool { return e.Path == stop })
+
+		created := expect(temp.Join("new.txt").AsFile().WriteString("data", 0o644))
+		time.Sleep(50 * time.Millisecond)
+		expect(existing.Append([]byte("more"), 0o644))
+		enforce(existing.Chmod(0o600))
+		moved := expect(created.Rename(temp.Join("moved.txt")))
+		expect(temp.Join("sub").AsDir().Make(0o755))
+		time.Sleep(50 * time.Millisecond)
+		enforce(moved.Remove())
+		time.Sleep(50 * time.Millisecond)
+		expect(stop.AsFile().WriteString("", 0o644))
+
+		events := wait()
+		expected := map[pathlib.PathStr]pathlib.EventOp{
+			pathlib.PathStr(created):  pathlib.Create | pathlib.Rename,
+			pathlib.PathStr(existing): pathlib.Write | pathlib.Chmod,
+			pathlib.PathStr(moved):    pathlib.Create | pathlib.Remove,
+		}
+		for p, op := range expected {
+			if actual := opsFor(events, p); !actual.Has(op) {
+				t.Errorf("expected %s for %s, got %s in %v", op, p, actual, events)
+			}
+		}
+		i := slices.IndexFunc(events, func(e pathlib.Event) bool { return e.Path == temp.Join("sub") })
+		if i < 0 || !events[i].IsDir || events[i].Typed() != temp.Join("sub").AsDir() {
+			t.Errorf("expected a directory event for sub, got %v", events)
+		}
+	})
+}
+
+func TestDir_Watch_symlink(t *testing.T) {
+	eachWatcher(t, func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions) {
+		stop := temp.Join("stop")
+		wait := watching(t, func(ctx context.Context) func(func(pathlib.Event, error) bool) {
+			return temp.Watch(ctx, opts)
+		}, func(e pathlib.Event) bool { return e.Path == stop })
+
+		link := expect(temp.Join("link").AsSymlink().LinkTo("target.txt"))
+		time.Sleep(50 * time.Millisecond)
+		expect(stop.AsFile().WriteString("", 0o644))
+
+		events := wait()
+		i := slices.IndexFunc(events, func(e pathlib.Event) bool { return e.Path == pathlib.PathStr(link) })
+		if i < 0 || !events[i].IsSymlink || events[i].Typed() != link {
+			t.Errorf("expected a symlink event for link, got %v", events)
+		}
+		if i := slices.IndexFunc(events, func(e pathlib.Event) bool { return e.Path == stop }); events[i].IsSymlink {
+			t.Errorf("expected %s not to be a symlink", stop)
+		}
+	})
+}
+
+func TestDir_Watch_recursive(t *testing.T) {
+	eachWatcher(t, func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions) {
+		opts.Recursive = true
+		nested := expect(temp.Join("a/b").AsDir().MakeAll(0o755, 0o755))
+		stop := temp.Join("a/new/stop")
+		wait := watching(t, func(ctx context.Context) func(func(pathlib.Event, error) bool) {
+			return temp.Watch(ctx, opts)
+		}, func(e pathlib.Event) bool { return e.Path == stop })
+
+		expect(nested.Join("deep.txt").AsFile().WriteString("", 0o644))
+		expect(stop.AsFile().MakeAll(0o644, 0o755))
+		events := wait()
+		if !opsFor(events, nested.Join("deep.txt")).Has(pathlib.Create) {
+			t.Errorf("expected a create event in an existing subdirectory, got %v", events)
+		}
+	})
+}
+
+func TestDir_Watch_debounce(t *testing.T) {
+	eachWatcher(t, func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions) {
+		opts.Debounce = 100 * time.Millisecond
+		file := temp.Join("burst.txt").AsFile()
+		wait := watching(t, func(ctx context.Context) func(func(pathlib.Event, error) bool) {
+			return temp.Watch(ctx, opts)
+		}, func(e pathlib.Event) bool { return e.Path == pathlib.PathStr(file) })
+
+		expect(file.WriteString("1", 0o644))
+		for _, data := range []string{"2", "3", "4"} {
+			time.Sleep(5 * time.Millisecond)
+			expect(file.Append([]byte(data), 0o644))
+		}
+		events := wait()
+		if len(events) != 1 || !events[0].Op.Has(pathlib.Create) {
+			t.Errorf("expected a single coalesced event, got %v", events)
+		}
+	})
+}
+
+func TestFile_Watch(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		config := expect(temp.Join("config.toml").AsFile().WriteString("a = 1", 0o644))
+		wait := watching(t, func(ctx context.Context) func(func(pathlib.Event, error) bool) {
+			return config.Watch(ctx)
+		}, func(e pathlib.Event) bool { return e.Op.Has(pathlib.Create) })
+
+		expect(temp.Join("unrelated.txt").AsFile().WriteString("", 0o644))
+		time.Sleep(600 * time.Millisecond) // longer than the default poll interval
+		expect(config.WriteAtomic([]byte("a = 2"), 0o644))
+		for _, e := range wait() {
+			if e.Path != pathlib.PathStr(config) {
+				t.Errorf("unexpected event %v", e)
+			}
+		}
+	})
+}
+
+func TestDir_Watch_removed(t *testing.T) {
+	eachWatcher(t, func(t *testing.T, temp pathlib.Dir, opts pathlib.WatchOptions) {
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		done := make(chan []pathlib.Event)
+		go func() {
+			var events []pathlib.Event
+			for e, err := range dir.Watch(ctx, opts) {
+				enforce(err)
+				events = append(events, e)
+			}
+			done <- events
+		}()
+		time.Sleep(50 * time.Millisecond)
+		expect(dir.RemoveAll())
+		events := <-done
+		if ctx.Err() != nil {
+			t.Fatal("expected the watch to end when the directory was removed")
+		}
+		if last := events[len(events)-1]; last.Path != pathlib.PathStr(dir) || last.Op != pathlib.Remove {
+			t.Errorf("expected a final remove event, got %v", events)
+		}
+
+		for _, err := range temp.Join("missing").AsDir().Watch(ctx, opts) {
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("expected fs.ErrNotExist, got %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package pathlib
+
+// A path using Windows syntax on any OS: "\" and "/" are separators, and paths may
+// start with a drive letter ("C:"), a UNC share ("\\host\share") or a device prefix
+// ("\\?\"). Comparisons ignore case.
+//
+// Methods required by [PurePath] and [PureTransformer] return [PathStr] and take [Dir]
+// values, which hold Windows syntax unchanged; convert them back with a cast, e.g.
+// WindowsPath(p.Join("x")).
+type WindowsPath string
+
+var (
+	_ PurePath                     = WindowsPath("")
+	_ Stemmer                      = WindowsPath("")
+	_ PureTransformer[WindowsPath] = WindowsPath("")
+)
+
+// PurePath --------------------------------------------------------------------
+
+// Join implements [PurePath].
+func (p WindowsPath) Join(segments ...string) PathStr {
+	return PathStr(windowsFlavor.join(append([]string{string(p)}, segments...)...))
+}
+
+// Parent implements [PurePath].
+func (p WindowsPath) Parent() Dir {
+	return Dir(windowsFlavor.dir(windowsFlavor.clean(string(p))))
+}
+
+// BaseName implements [PurePath].
+func (p WindowsPath) BaseName() string {
+	return windowsFlavor.base(string(p))
+}
+
+// Ext implements [PurePath].
+func (p WindowsPath) Ext() string {
+	return windowsFlavor.ext(string(p))
+}
+
+// Parts implements [PurePath].
+func (p WindowsPath) Parts() []string {
+	return windowsFlavor.parts(string(p))
+}
+
+// IsAbsolute implements [PurePath].
+func (p WindowsPath) IsAbsolute() bool {
+	return windowsFlavor.isAbs(string(p))
+}
+
+// IsLocal implements [PurePath].
+func (p WindowsPath) IsLocal() bool {
+	return windowsFlavor.isLocal(string(p))
+}
+
+// See [PathStr.Stem].
+//
+// Stem implements [Stemmer].
+func (p WindowsPath) Stem() string {
+	return windowsFlavor.stem(string(p))
+}
+
+// See [PathStr.Suffixes].
+//
+// Suffixes implements [Stemmer].
+func (p WindowsPath) Suffixes() []string {
+	return windowsFlavor.suffixes(string(p))
+}
+
+// See [PathStr.IsRelativeTo].
+//
+// IsRelativeTo implements [PureTransformer].
+func (p WindowsPath) IsRelativeTo(base Dir) bool {
+	_, ok := windowsFlavor.trimBase(string(p), string(base))
+	return ok
+}
+
+// PureTransformer -------------------------------------------------------------
+
+// String implements [PureTransformer].
+func (p WindowsPath) String() string {
+	return string(p)
+}
+
+// Clean implements [PureTransformer].
+func (p WindowsPath) Clean() WindowsPath {
+	return WindowsPath(windowsFlavor.clean(string(p)))
+}
+
+// Returns true if the cleaned paths are equal, ignoring case. Unlike [PathStr.Eq], relative
+// paths are never made absolute.
+//
+// Eq implements [PureTransformer].
+func (p WindowsPath) Eq(other WindowsPath) bool {
+	return windowsFlavor.sameWord(windowsFlavor.clean(string(p)), windowsFlavor.clean(string(other)))
+}
+
+// Rel implements [PureTransformer].
+func (p WindowsPath) Rel(base Dir) (WindowsPath, error) {
+	result, err := windowsFlavor.rel(string(base), string(p))
+	return WindowsPath(result), err
+}
+
+// Converts a slash-separated [io/fs] path, replacing slashes with backslashes and rejecting
+// reserved names like "NUL".
+//
+// Localize implements [PureTransformer].
+func (p WindowsPath) Localize() (WindowsPath, error) {
+	result, err := windowsFlavor.localize(string(p))
+	return WindowsPath(result), err
+}
+
+// See [PathStr.WithName].
+//
+// WithName implements [PureTransformer].
+func (p WindowsPath) WithName(name string) (WindowsPath, error) {
+	result, err := windowsFlavor.withName(string(p), name)
+	return WindowsPath(result), err
+}
+
+// See [PathStr.WithStem].
+//
+// WithStem implements [PureTransformer].
+func (p WindowsPath) WithStem(stem string) (WindowsPath, error) {
+	result, err := windowsFlavor.withStem(string(p), stem)
+	return WindowsPath(result), err
+}
+
+// See [PathStr.WithSuffix].
+//
+// WithSuffix implements [PureTransformer].
+func (p WindowsPath) WithSuffix(suffix string) (WindowsPath, error) {
+	result, err := windowsFlavor.withSuffix(string(p), suffix)
+	return WindowsPath(result), err
+}
+
+// See [PathStr.RelativeTo].
+//
+// RelativeTo implements [PureTransformer].
+func (p WindowsPath) RelativeTo(base Dir) (WindowsPath, error) {
+	result, err := windowsFlavor.relativeTo(string(p), string(base))
+	return WindowsPath(result), err
+}
+
+// conversions -----------------------------------------------------------------
+
+// Returns the path with forward slashes, keeping any volume name, e.g. "C:/Users".
+func (p WindowsPath) AsPosix() PosixPath {
+	return PosixPath(windowsFlavor.convert(string(p), posixFlavor))
+}
+
+// Returns the path with the current OS's separators. See [PathStr.AsWindows].
+func (p WindowsPath) AsPathStr() PathStr {
+	return PathStr(windowsFlavor.convert(string(p), hostFlavor))
+}
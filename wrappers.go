@@ -10,18 +10,31 @@ import (
 
 // See [os.Stat].
 func stat[P Kind](p P) (Info[P], error) {
-	info, err := os.Stat(string(p))
+	info, err := observe(string(p), true)
 	return onDisk[P]{p, info}, err
 }
 
 func lstat[P Kind](p P) (Info[P], error) {
-	info, err := os.Lstat(string(p))
+	info, err := observe(string(p), false)
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil, err
 	}
 	return onDisk[P]{p, info}, err
 }
 
+// stats name through its backend. Paths served by [OS] use [osStat], so that their
+// infos carry any platform-specific extras that package os doesn't report.
+func observe(name string, follow bool) (fs.FileInfo, error) {
+	fsys := filesystemOf(name)
+	if _, ok := fsys.(OS); ok {
+		return osStat(name, follow)
+	}
+	if follow {
+		return fsys.Stat(name)
+	}
+	return fsys.Lstat(name)
+}
+
 func exists[P Kind](p P) bool {
 	_, err := stat(p)
 	return !errors.Is(err, fs.ErrNotExist)
@@ -103,19 +116,27 @@ func expandUser[P Kind](p P) (q P, err error) {
 	return
 }
 
+// the permission and special bits that chmod can change.
+const chmodMask = fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+
 // See [os.Chmod].
 func chmod[P Kind](p P, mode os.FileMode) error {
-	return os.Chmod(string(p), mode)
+	return filesystemOf(string(p)).Chmod(string(p), mode)
 }
 
 func chown[P Kind](p P, uid int, gid int) error {
-	return os.Chown(string(p), uid, gid)
+	return filesystemOf(string(p)).Chown(string(p), uid, gid)
 }
 
 // See [os.Rename]
 func rename[P Kind](p P, newPath PathStr) (result P, err error) {
 	result = p
-	err = os.Rename(string(p), string(newPath))
+	if !sameBinding(string(p), string(newPath)) {
+		// renaming between backends is like renaming across devices
+		err = &os.LinkError{Op: "rename", Old: string(p), New: string(newPath), Err: errCrossDevice}
+		return
+	}
+	err = filesystemOf(string(p)).Rename(string(p), string(newPath))
 	if err == nil {
 		result = P(newPath)
 	}
@@ -123,10 +144,92 @@ func rename[P Kind](p P, newPath PathStr) (result P, err error) {
 }
 
 func remove[P Kind](p P) error {
-	return os.Remove(string(p))
+	return filesystemOf(string(p)).Remove(string(p))
 }
 
 // See [os.RemoveAll]
 func removeAll[P Kind](p P) (P, error) {
-	return p, os.RemoveAll(string(p))
+	return p, filesystemOf(string(p)).RemoveAll(string(p))
+}
+
+// See [os.OpenFile].
+func openFile[P Kind](p P, flag int, perm fs.FileMode) (*handle, error) {
+	f, err := filesystemOf(string(p)).OpenFile(string(p), flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &handle{f}, nil
+}
+
+// See [os.ReadFile].
+func readFile[P Kind](p P) ([]byte, error) {
+	return filesystemOf(string(p)).ReadFile(string(p))
+}
+
+// See [os.ReadDir].
+func readDir[P Kind](p P) ([]fs.DirEntry, error) {
+	return filesystemOf(string(p)).ReadDir(string(p))
+}
+
+// See [os.Mkdir].
+func mkdir[P Kind](p P, perm fs.FileMode) error {
+	return filesystemOf(string(p)).Mkdir(string(p), perm)
+}
+
+// See [os.MkdirAll].
+func mkdirAll[P Kind](p P, perm fs.FileMode) error {
+	return filesystemOf(string(p)).MkdirAll(string(p), perm)
+}
+
+// See [os.Symlink].
+func symlink[P Kind](p P, target PathStr) error {
+	return filesystemOf(string(p)).Symlink(string(target), string(p))
+}
+
+// See [os.Readlink].
+func readlink[P Kind](p P) (PathStr, error) {
+	link, err := filesystemOf(string(p)).Readlink(string(p))
+	return PathStr(link), err
+}
+
+// A version of [path/filepath.WalkDir] that dispatches through the bound [Filesystem].
+func walkDir(root string, fn fs.WalkDirFunc) error {
+	info, err := filesystemOf(root).Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDirEntry(root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walkDirEntry(path string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, d, nil); err != nil || !d.IsDir() {
+		if err == filepath.SkipDir && d.IsDir() {
+			err = nil // successfully skipped directory
+		}
+		return err
+	}
+	entries, err := filesystemOf(path).ReadDir(path)
+	if err != nil {
+		// second call, to report the ReadDir error
+		if err = fn(path, d, err); err != nil {
+			if err == filepath.SkipDir && d.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	for _, entry := range entries {
+		if err := walkDirEntry(filepath.Join(path, entry.Name()), entry, fn); err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,249 @@
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// Implemented by [Filesystem] backends that support extended attributes. Paths bound
+// to backends that don't implement it fail with [errors.ErrUnsupported].
+//
+// Each method follows a final symlink unless follow is false, in which case it acts
+// on the link itself like lgetxattr(2) and friends.
+type XattrFilesystem interface {
+	// See getxattr(2).
+	Getxattr(name, attr string, follow bool) ([]byte, error)
+	// See setxattr(2).
+	Setxattr(name, attr string, value []byte, follow bool) error
+	// See listxattr(2).
+	Listxattr(name string, follow bool) ([]string, error)
+	// See removexattr(2).
+	Removexattr(name, attr string, follow bool) error
+}
+
+var _ XattrFilesystem = OS{}
+
+func xattrFilesystemOf(name, op string) (XattrFilesystem, error) {
+	if fsys, ok := filesystemOf(name).(XattrFilesystem); ok {
+		return fsys, nil
+	}
+	return nil, &fs.PathError{Op: op, Path: name, Err: errors.ErrUnsupported}
+}
+
+func getXattr[P Kind](p P, attr string, follow bool) ([]byte, error) {
+	fsys, err := xattrFilesystemOf(string(p), "getxattr")
+	if err != nil {
+		return nil, err
+	}
+	return fsys.Getxattr(string(p), attr, follow)
+}
+
+func setXattr[P Kind](p P, attr string, value []byte, follow bool) error {
+	fsys, err := xattrFilesystemOf(string(p), "setxattr")
+	if err != nil {
+		return err
+	}
+	return fsys.Setxattr(string(p), attr, value, follow)
+}
+
+func listXattr[P Kind](p P, follow bool) ([]string, error) {
+	fsys, err := xattrFilesystemOf(string(p), "listxattr")
+	if err != nil {
+		return nil, err
+	}
+	return fsys.Listxattr(string(p), follow)
+}
+
+func removeXattr[P Kind](p P, attr string, follow bool) error {
+	fsys, err := xattrFilesystemOf(string(p), "removexattr")
+	if err != nil {
+		return err
+	}
+	return fsys.Removexattr(string(p), attr, follow)
+}
+
+// reads every attribute returned by list.
+func xattrs(list func() ([]string, error), get func(string) ([]byte, error)) (map[string][]byte, error) {
+	names, err := list()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]byte, len(names))
+	for _, name := range names {
+		value, err := get(name)
+		if errors.Is(err, errNoXattr) {
+			continue // removed since it was listed
+		} else if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// PathStr ---------------------------------------------------------------------
+
+// Returns the value of an extended attribute, following symlinks. A missing attribute
+// is reported as ENODATA (ENOATTR on BSDs).
+func (p PathStr) GetXattr(name string) ([]byte, error) {
+	return getXattr(p, name, true)
+}
+
+// Creates or replaces an extended attribute, following symlinks.
+func (p PathStr) SetXattr(name string, value []byte) error {
+	return setXattr(p, name, value, true)
+}
+
+// Returns the names of the path's extended attributes in lexical order, following symlinks.
+func (p PathStr) ListXattr() ([]string, error) {
+	return listXattr(p, true)
+}
+
+// Removes an extended attribute, following symlinks.
+func (p PathStr) RemoveXattr(name string) error {
+	return removeXattr(p, name, true)
+}
+
+// Returns a snapshot of every extended attribute, following symlinks.
+func (p PathStr) Xattrs() (map[string][]byte, error) {
+	return xattrs(p.ListXattr, p.GetXattr)
+}
+
+// File ------------------------------------------------------------------------
+
+// See [PathStr.GetXattr].
+func (f File) GetXattr(name string) ([]byte, error) {
+	return getXattr(f, name, true)
+}
+
+// See [PathStr.SetXattr].
+func (f File) SetXattr(name string, value []byte) error {
+	return setXattr(f, name, value, true)
+}
+
+// See [PathStr.ListXattr].
+func (f File) ListXattr() ([]string, error) {
+	return listXattr(f, true)
+}
+
+// See [PathStr.RemoveXattr].
+func (f File) RemoveXattr(name string) error {
+	return removeXattr(f, name, true)
+}
+
+// See [PathStr.Xattrs].
+func (f File) Xattrs() (map[string][]byte, error) {
+	return xattrs(f.ListXattr, f.GetXattr)
+}
+
+// Dir -------------------------------------------------------------------------
+
+// See [PathStr.GetXattr].
+func (d Dir) GetXattr(name string) ([]byte, error) {
+	return getXattr(d, name, true)
+}
+
+// See [PathStr.SetXattr].
+func (d Dir) SetXattr(name string, value []byte) error {
+	return setXattr(d, name, value, true)
+}
+
+// See [PathStr.ListXattr].
+func (d Dir) ListXattr() ([]string, error) {
+	return listXattr(d, true)
+}
+
+// See [PathStr.RemoveXattr].
+func (d Dir) RemoveXattr(name string) error {
+	return removeXattr(d, name, true)
+}
+
+// See [PathStr.Xattrs].
+func (d Dir) Xattrs() (map[string][]byte, error) {
+	return xattrs(d.ListXattr, d.GetXattr)
+}
+
+// Symlink ---------------------------------------------------------------------
+
+// Returns the value of one of the link's own extended attributes. See lgetxattr(2).
+// Note that Linux doesn't allow user.* attributes on symlinks.
+func (s Symlink) GetXattr(name string) ([]byte, error) {
+	return getXattr(s, name, false)
+}
+
+// Creates or replaces one of the link's own extended attributes. See lsetxattr(2).
+func (s Symlink) SetXattr(name string, value []byte) error {
+	return setXattr(s, name, value, false)
+}
+
+// Returns the names of the link's own extended attributes. See llistxattr(2).
+func (s Symlink) ListXattr() ([]string, error) {
+	return listXattr(s, false)
+}
+
+// Removes one of the link's own extended attributes. See lremovexattr(2).
+func (s Symlink) RemoveXattr(name string) error {
+	return removeXattr(s, name, false)
+}
+
+// Returns a snapshot of the link's own extended attributes.
+func (s Symlink) Xattrs() (map[string][]byte, error) {
+	return xattrs(s.ListXattr, s.GetXattr)
+}
+
+// FileHandle ------------------------------------------------------------------
+
+// GetXattr implements [FileHandle].
+func (h *handle) GetXattr(name string) ([]byte, error) {
+	if f, ok := h.RawFile.(*os.File); ok {
+		return fgetxattr(f, name)
+	}
+	return getXattr(h.Path(), name, true)
+}
+
+// SetXattr implements [FileHandle].
+func (h *handle) SetXattr(name string, value []byte) error {
+	if f, ok := h.RawFile.(*os.File); ok {
+		return fsetxattr(f, name, value)
+	}
+	return setXattr(h.Path(), name, value, true)
+}
+
+// ListXattr implements [FileHandle].
+func (h *handle) ListXattr() ([]string, error) {
+	if f, ok := h.RawFile.(*os.File); ok {
+		return flistxattr(f)
+	}
+	return listXattr(h.Path(), true)
+}
+
+// RemoveXattr implements [FileHandle].
+func (h *handle) RemoveXattr(name string) error {
+	if f, ok := h.RawFile.(*os.File); ok {
+		return fremovexattr(f, name)
+	}
+	return removeXattr(h.Path(), name, true)
+}
+
+// Xattrs implements [FileHandle].
+func (h *handle) Xattrs() (map[string][]byte, error) {
+	return xattrs(h.ListXattr, h.GetXattr)
+}
+
+// splits a NUL-separated list of names, sorting them.
+func splitXattrNames(buf []byte) []string {
+	var names []string
+	start := 0
+	for i, b := range buf {
+		if b == 0 {
+			if i > start {
+				names = append(names, string(buf[start:i]))
+			}
+			start = i + 1
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,8 @@
+//go:build darwin || freebsd || netbsd
+
+package pathlib
+
+import "golang.org/x/sys/unix"
+
+// The error for a missing extended attribute.
+const errNoXattr = unix.ENOATTR
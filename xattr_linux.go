@@ -0,0 +1,6 @@
+package pathlib
+
+import "golang.org/x/sys/unix"
+
+// The error for a missing extended attribute.
+const errNoXattr = unix.ENODATA
@@ -0,0 +1,52 @@
+//go:build !(darwin || freebsd || linux || netbsd)
+
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// The error for a missing extended attribute in a [MemFS].
+var errNoXattr = errors.New("no such extended attribute")
+
+// Getxattr implements [XattrFilesystem].
+func (OS) Getxattr(name, _ string, _ bool) ([]byte, error) {
+	return nil, unsupportedXattr("getxattr", name)
+}
+
+// Setxattr implements [XattrFilesystem].
+func (OS) Setxattr(name, _ string, _ []byte, _ bool) error {
+	return unsupportedXattr("setxattr", name)
+}
+
+// Listxattr implements [XattrFilesystem].
+func (OS) Listxattr(name string, _ bool) ([]string, error) {
+	return nil, unsupportedXattr("listxattr", name)
+}
+
+// Removexattr implements [XattrFilesystem].
+func (OS) Removexattr(name, _ string, _ bool) error {
+	return unsupportedXattr("removexattr", name)
+}
+
+func fgetxattr(f *os.File, _ string) ([]byte, error) {
+	return nil, unsupportedXattr("fgetxattr", f.Name())
+}
+
+func fsetxattr(f *os.File, _ string, _ []byte) error {
+	return unsupportedXattr("fsetxattr", f.Name())
+}
+
+func flistxattr(f *os.File) ([]string, error) {
+	return nil, unsupportedXattr("flistxattr", f.Name())
+}
+
+func fremovexattr(f *os.File, _ string) error {
+	return unsupportedXattr("fremovexattr", f.Name())
+}
+
+func unsupportedXattr(op, name string) error {
+	return &fs.PathError{Op: op, Path: name, Err: errors.ErrUnsupported}
+}
@@ -0,0 +1,83 @@
+package pathlib_test
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/skalt/pathlib.go"
+)
+
+// Skips the test if the filesystem doesn't support user.* extended attributes.
+func requireXattrs(t *testing.T, p pathlib.PathStr) {
+	t.Helper()
+	err := p.SetXattr("user.pathlib.probe", nil)
+	if errors.Is(err, errors.ErrUnsupported) || errors.Is(err, errOpNotSupp) {
+		t.Skipf("extended attributes aren't supported: %v", err)
+	}
+	enforce(err)
+	enforce(p.RemoveXattr("user.pathlib.probe"))
+}
+
+func TestFile_xattr(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		requireXattrs(t, pathlib.PathStr(temp))
+		file := expect(temp.Join("artifact.tar").AsFile().WriteString("", 0o644))
+		enforce(file.SetXattr("user.build", []byte("1234")))
+		enforce(file.SetXattr("user.arch", []byte("amd64")))
+
+		if value := string(expect(file.GetXattr("user.build"))); value != "1234" {
+			t.Errorf("expected %q, got %q", "1234", value)
+		}
+		if names := expect(file.ListXattr()); !slices.Equal(names, []string{"user.arch", "user.build"}) {
+			t.Errorf("unexpected names %q", names)
+		}
+		if all := expect(pathlib.PathStr(file).Xattrs()); len(all) != 2 || string(all["user.arch"]) != "amd64" {
+			t.Errorf("unexpected snapshot %q", all)
+		}
+		enforce(file.RemoveXattr("user.arch"))
+		if _, err := file.GetXattr("user.arch"); err == nil {
+			t.Error("expected an error getting a removed attribute")
+		}
+
+		h := expect(file.Open(os.O_RDWR, 0))
+		defer func() { enforce(h.Close()) }()
+		enforce(h.SetXattr("user.from-handle", []byte("yes")))
+		if value := string(expect(file.GetXattr("user.from-handle"))); value != "yes" {
+			t.Errorf("expected the handle's attribute to be visible via the path, got %q", value)
+		}
+		if names := expect(h.ListXattr()); !slices.Equal(names, []string{"user.build", "user.from-handle"}) {
+			t.Errorf("unexpected names %q", names)
+		}
+		enforce(h.RemoveXattr("user.build"))
+		if all := expect(h.Xattrs()); len(all) != 1 {
+			t.Errorf("unexpected snapshot %q", all)
+		}
+	})
+}
+
+func TestDir_xattr(t *testing.T) {
+	eachBackend(t, func(t *testing.T, temp pathlib.Dir) {
+		requireXattrs(t, pathlib.PathStr(temp))
+		dir := expect(temp.Join("dir").AsDir().Make(0o755))
+		link := expect(temp.Join("link").AsSymlink().LinkTo("dir"))
+		enforce(pathlib.Dir(link).SetXattr("user.tag", []byte("followed")))
+		if value := string(expect(dir.GetXattr("user.tag"))); value != "followed" {
+			t.Errorf("expected the attribute to be set on the link's target, got %q", value)
+		}
+		if _, err := link.GetXattr("user.tag"); err == nil {
+			t.Error("expected the link itself to have no attribute")
+		}
+	})
+}
+
+func TestXattr_unsupported(t *testing.T) {
+	// hides the OS backend's extended attribute methods
+	fsys := struct{ pathlib.Filesystem }{pathlib.OS{}}
+	unbind := pathlib.Bind("/unsupported", fsys)
+	defer unbind()
+	if _, err := pathlib.File("/unsupported/file").GetXattr("user.x"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected errors.ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+//go:build darwin || freebsd || linux || netbsd
+
+package pathlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Getxattr implements [XattrFilesystem].
+func (OS) Getxattr(name, attr string, follow bool) ([]byte, error) {
+	get := unix.Getxattr
+	if !follow {
+		get = unix.Lgetxattr
+	}
+	value, err := readXattrBuffer(func(dest []byte) (int, error) { return get(name, attr, dest) })
+	return value, xattrError("getxattr", name, err)
+}
+
+// Setxattr implements [XattrFilesystem].
+func (OS) Setxattr(name, attr string, value []byte, follow bool) error {
+	set := unix.Setxattr
+	if !follow {
+		set = unix.Lsetxattr
+	}
+	return xattrError("setxattr", name, set(name, attr, value, 0))
+}
+
+// Listxattr implements [XattrFilesystem].
+func (OS) Listxattr(name string, follow bool) ([]string, error) {
+	list := unix.Listxattr
+	if !follow {
+		list = unix.Llistxattr
+	}
+	buf, err := readXattrBuffer(func(dest []byte) (int, error) { return list(name, dest) })
+	if err != nil {
+		return nil, xattrError("listxattr", name, err)
+	}
+	return splitXattrNames(buf), nil
+}
+
+// Removexattr implements [XattrFilesystem].
+func (OS) Removexattr(name, attr string, follow bool) error {
+	remove := unix.Removexattr
+	if !follow {
+		remove = unix.Lremovexattr
+	}
+	return xattrError("removexattr", name, remove(name, attr))
+}
+
+func fgetxattr(f *os.File, attr string) (value []byte, err error) {
+	err = control(f, func(fd uintptr) (err error) {
+		value, err = readXattrBuffer(func(dest []byte) (int, error) { return unix.Fgetxattr(int(fd), attr, dest) })
+		return
+	})
+	return value, xattrError("fgetxattr", f.Name(), err)
+}
+
+func fsetxattr(f *os.File, attr string, value []byte) error {
+	err := control(f, func(fd uintptr) error { return unix.Fsetxattr(int(fd), attr, value, 0) })
+	return xattrError("fsetxattr", f.Name(), err)
+}
+
+func flistxattr(f *os.File) (names []string, err error) {
+	err = control(f, func(fd uintptr) error {
+		buf, err := readXattrBuffer(func(dest []byte) (int, error) { return unix.Flistxattr(int(fd), dest) })
+		names = splitXattrNames(buf)
+		return err
+	})
+	if err != nil {
+		return nil, xattrError("flistxattr", f.Name(), err)
+	}
+	return names, nil
+}
+
+func fremovexattr(f *os.File, attr string) error {
+	err := control(f, func(fd uintptr) error { return unix.Fremovexattr(int(fd), attr) })
+	return xattrError("fremovexattr", f.Name(), err)
+}
+
+// calls read with a buffer big enough for the result, which may grow between calls.
+func readXattrBuffer(read func(dest []byte) (int, error)) ([]byte, error) {
+	for {
+		size, err := read(nil)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return []byte{}, nil
+		}
+		buf := make([]byte, size)
+		n, err := read(buf)
+		if errors.Is(err, unix.ERANGE) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+}
+
+func xattrError(op, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}